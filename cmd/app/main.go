@@ -9,12 +9,18 @@ import (
 	"subscriptions/internal/repositories"
 	"subscriptions/internal/services"
 	"subscriptions/internal/transport/http/handlers"
+	"subscriptions/internal/transport/http/health"
+	"subscriptions/internal/transport/http/middleware"
 	"subscriptions/pkg/logger"
 	"subscriptions/pkg/postgres"
+	"subscriptions/pkg/tracing"
 	"syscall"
 	"time"
 
 	"github.com/go-chi/chi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	httpSwagger "github.com/swaggo/http-swagger"
 	"go.uber.org/zap"
 
@@ -28,13 +34,33 @@ import (
 func main() {
 	ctx := context.Background()
 
+	// До загрузки конфига доступен только логгер по умолчанию
+	cfg, err := config.New()
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Fatal(ctx, "unable to load config", zap.Error(err))
+		return
+	}
+
+	ctx, err = logger.New(ctx, cfg)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Fatal(ctx, "unable to create logger", zap.Error(err))
+		return
+	}
+
 	log := logger.GetLoggerFromCtx(ctx)
+	defer log.Sync()
 
-	cfg, err := config.New()
+	// Провайдер трейсов настраивается до пула, чтобы трейсер pgx сразу писал в выбранный экспортер
+	shutdownTracing, err := tracing.New(ctx, cfg)
 	if err != nil {
-		log.Fatal(ctx, "unable to load config", zap.Error(err))
+		log.Fatal(ctx, "unable to set up tracing", zap.Error(err))
 		return
 	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Error(ctx, "unable to flush traces", zap.Error(err))
+		}
+	}()
 
 	db, err := postgres.New(ctx, cfg)
 	if err != nil {
@@ -44,13 +70,47 @@ func main() {
 
 	logger.GetLoggerFromCtx(ctx).Info(ctx, "Successful start!")
 
+	// Отдельный реестр вместо глобального, чтобы в /metrics попадали только метрики сервиса
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		postgres.NewStatsCollector(db),
+	)
+
 	r := chi.NewRouter()
-	repository := repositories.New(db)
 
-	service := services.New(repository)
+	// Общий логгер и ID запроса нужны access-логу, поэтому они подключаются раньше него.
+	// Трейсинг идет первым, чтобы trace_id попадал во все записи запроса, включая access-лог
+	r.Use(middleware.Tracing, middleware.Logger(log), middleware.RequestId, middleware.AccessLog, middleware.Metrics(registry),
+		middleware.Principal(cfg.PrincipalHeader))
+
+	// Адаптер пула дает репозиторию транзакции WithTx
+	repository := repositories.New(repositories.NewPgxPoolAdapter(db))
+
+	service := services.WithTracing(services.WithMetrics(services.New(repository), registry))
 
 	handlers := handlers.New(service)
 
+	// Очистка мягко удаленных подписок работает в фоне и останавливается вместе с сервером
+	purgeCtx, stopPurge := context.WithCancel(ctx)
+	defer stopPurge()
+
+	if cfg.PurgeInterval > 0 {
+		go services.NewPurger(repository, cfg.SoftDeleteRetention, cfg.PurgeInterval).Run(purgeCtx)
+	}
+
+	// Ответы роутера на неизвестный маршрут и неподдерживаемый метод тоже в формате application/problem+json
+	r.NotFound(handlers.NotFound)
+	r.MethodNotAllowed(handlers.MethodNotAllowed)
+
+	healthHandler := health.New(db)
+
+	r.Get("/health/live", healthHandler.Live)
+	r.Get("/health/ready", healthHandler.Ready)
+
+	r.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
 	r.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("/swagger/doc.json"), // URL для JSON документации
 	))
@@ -60,10 +120,27 @@ func main() {
 		r.Get("/", handlers.GetList) // /api/subscriptions?page=1&limit=10
 		r.Get("/{id}", handlers.Get)
 		r.Put("/{id}", handlers.Put)
+		r.Patch("/{id}", handlers.Patch)
 		r.Delete("/{id}", handlers.Delete)
+		r.Post("/{id}/restore", handlers.Restore)
+		r.Get("/{id}/history", handlers.GetHistory)
+		r.Post("/{id}/price-changes", handlers.AddPriceChange)
+		r.Get("/{id}/price-changes", handlers.GetPriceChanges)
+		r.Get("/summary/{user_id}", handlers.GetUserSummary)
+		r.Get("/summary/{user_id}/monthly", handlers.GetMonthlyBreakdown)
 		r.Get("/summary/{user_id}/{service_name}", handlers.GetSummary)
 	})
 
+	// GET возвращает текущий уровень логов, PUT {"level":"debug"} меняет его без перезапуска
+	r.Method(http.MethodGet, "/api/admin/log-level", log.LevelHandler())
+	r.Method(http.MethodPut, "/api/admin/log-level", log.LevelHandler())
+
+	r.Route("/api/admin/exchange-rates", func(r chi.Router) {
+		r.Get("/", handlers.GetExchangeRates) // /api/admin/exchange-rates?currency=USD
+		r.Put("/{currency}/{valid_from}", handlers.UpsertExchangeRate)
+		r.Delete("/{currency}/{valid_from}", handlers.DeleteExchangeRate)
+	})
+
 	server := &http.Server{
 		Addr:         ":8080",
 		Handler:      r,
@@ -92,6 +169,10 @@ func main() {
 	case <-shutdown:
 		log.Info(ctx, "Starting graceful shutdown")
 
+		// Новые запросы больше не принимаем, текущие дорабатывают
+		healthHandler.Shutdown()
+		stopPurge()
+
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
@@ -0,0 +1,3 @@
+ALTER TABLE subscriptions
+    DROP COLUMN billing_interval,
+    DROP COLUMN billing_period;
@@ -0,0 +1,4 @@
+DROP TABLE exchange_rates;
+
+ALTER TABLE subscriptions
+    DROP COLUMN currency;
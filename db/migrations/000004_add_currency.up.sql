@@ -0,0 +1,11 @@
+ALTER TABLE subscriptions
+    ADD COLUMN currency TEXT NOT NULL DEFAULT 'RUB'
+        CHECK (currency ~ '^[A-Z]{3}$');
+
+-- Курс: сколько рублей стоит 1 единица валюты, действует с месяца valid_from до следующего курса
+CREATE TABLE exchange_rates (
+    currency TEXT NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    valid_from DATE NOT NULL,
+    rate NUMERIC(18, 6) NOT NULL CHECK (rate > 0),
+    PRIMARY KEY (currency, valid_from)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE subscriptions
+    ALTER COLUMN price TYPE INTEGER USING ROUND(price / 100.0)::INTEGER;
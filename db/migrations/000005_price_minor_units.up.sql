@@ -0,0 +1,3 @@
+-- Цена хранится в минимальных единицах валюты (копейках, центах)
+ALTER TABLE subscriptions
+    ALTER COLUMN price TYPE BIGINT USING price::BIGINT * 100;
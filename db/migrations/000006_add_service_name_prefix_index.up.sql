@@ -0,0 +1,2 @@
+-- Регистронезависимый поиск по префиксу названия сервиса: lower(service_name) LIKE 'prefix%'
+CREATE INDEX idx_subscriptions_service_name_prefix ON subscriptions (lower(service_name) text_pattern_ops);
@@ -0,0 +1,2 @@
+-- Версия для оптимистической блокировки: увеличивается при каждом изменении подписки и отдается клиенту в ETag
+ALTER TABLE subscriptions ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
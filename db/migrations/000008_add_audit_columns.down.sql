@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_subscriptions_updated_at;
+
+ALTER TABLE subscriptions
+    DROP COLUMN IF EXISTS updated_by,
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at;
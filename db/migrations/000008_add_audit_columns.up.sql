@@ -0,0 +1,10 @@
+-- Кто и когда создал и последний раз изменил подписку. Для уже существующих строк время неизвестно,
+-- им достается момент миграции, автор остается пустым
+ALTER TABLE subscriptions
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN created_by TEXT,
+    ADD COLUMN updated_by TEXT;
+
+-- Инкрементальная синхронизация: updated_since и сортировка по updated_at с id для keyset-пагинации
+CREATE INDEX idx_subscriptions_updated_at ON subscriptions (updated_at, id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_subscriptions_deleted_at;
+
+-- Без колонки удаленные подписки снова стали бы видны
+DELETE FROM subscriptions WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,5 @@
+-- Мягкое удаление: подписка скрывается из выборок, но ее можно восстановить до очистки по сроку хранения
+ALTER TABLE subscriptions ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- Задаче очистки нужны только удаленные подписки, их обычно немного
+CREATE INDEX idx_subscriptions_deleted_at ON subscriptions (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS subscriptions_record_event ON subscriptions;
+DROP FUNCTION IF EXISTS record_subscription_event();
+
+DROP TABLE IF EXISTS subscription_events;
+DROP FUNCTION IF EXISTS forbid_subscription_event_change();
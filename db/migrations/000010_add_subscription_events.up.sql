@@ -0,0 +1,57 @@
+-- Журнал изменений подписок. Строки только добавляются, снимки before/after хранят строку подписки целиком
+CREATE TABLE subscription_events (
+    id BIGSERIAL PRIMARY KEY,
+    -- Без внешнего ключа: история остается и после окончательного удаления подписки
+    subscription_id UUID NOT NULL,
+    event_type TEXT NOT NULL CHECK (event_type IN ('created', 'updated', 'deleted', 'restored', 'purged')),
+    actor TEXT,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    before JSONB,
+    after JSONB
+);
+
+CREATE INDEX idx_subscription_events_subscription_id ON subscription_events (subscription_id, id);
+
+-- Событие пишется триггером в той же транзакции, что и изменение подписки, поэтому ни одно изменение
+-- не пропадет из журнала. Автор берется из created_by и updated_by, которые заполняет репозиторий
+CREATE FUNCTION record_subscription_event() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO subscription_events (subscription_id, event_type, actor, after)
+        VALUES (NEW.id, 'created', NEW.created_by, to_jsonb(NEW));
+    ELSIF TG_OP = 'UPDATE' THEN
+        INSERT INTO subscription_events (subscription_id, event_type, actor, before, after)
+        VALUES (
+            NEW.id,
+            CASE
+                WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'deleted'
+                WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'restored'
+                ELSE 'updated'
+            END,
+            NEW.updated_by,
+            to_jsonb(OLD),
+            to_jsonb(NEW)
+        );
+    ELSE
+        -- Окончательное удаление выполняет задача очистки, автора у него нет
+        INSERT INTO subscription_events (subscription_id, event_type, before)
+        VALUES (OLD.id, 'purged', to_jsonb(OLD));
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER subscriptions_record_event
+    AFTER INSERT OR UPDATE OR DELETE ON subscriptions
+    FOR EACH ROW EXECUTE FUNCTION record_subscription_event();
+
+CREATE FUNCTION forbid_subscription_event_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'subscription_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER subscription_events_append_only
+    BEFORE UPDATE OR DELETE ON subscription_events
+    FOR EACH ROW EXECUTE FUNCTION forbid_subscription_event_change();
@@ -0,0 +1,10 @@
+-- Изменения цены подписки. Цена из subscriptions.price действует с start_date до первого изменения,
+-- каждое изменение действует с месяца effective_from до следующего. Валюта остается валютой подписки
+CREATE TABLE subscription_price_changes (
+    subscription_id UUID NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    effective_from DATE NOT NULL,
+    price BIGINT NOT NULL CHECK (price >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_by TEXT,
+    PRIMARY KEY (subscription_id, effective_from)
+);
@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS subscription_price_changes_record_event ON subscription_price_changes;
+DROP FUNCTION IF EXISTS record_price_change_event();
+
+-- Журнал только дополняется, поэтому запрет снимается на время удаления событий изменения цены
+ALTER TABLE subscription_events DISABLE TRIGGER subscription_events_append_only;
+DELETE FROM subscription_events WHERE event_type = 'price_changed';
+ALTER TABLE subscription_events ENABLE TRIGGER subscription_events_append_only;
+
+ALTER TABLE subscription_events DROP CONSTRAINT subscription_events_event_type_check;
+ALTER TABLE subscription_events ADD CONSTRAINT subscription_events_event_type_check
+    CHECK (event_type IN ('created', 'updated', 'deleted', 'restored', 'purged'));
+
+ALTER TABLE subscription_events DROP COLUMN price_change;
@@ -0,0 +1,26 @@
+-- Изменения цены тоже попадают в журнал подписки. Снимки before/after у такого события пусты,
+-- а само изменение хранится в price_change
+ALTER TABLE subscription_events ADD COLUMN price_change JSONB;
+
+ALTER TABLE subscription_events DROP CONSTRAINT subscription_events_event_type_check;
+ALTER TABLE subscription_events ADD CONSTRAINT subscription_events_event_type_check
+    CHECK (event_type IN ('created', 'updated', 'deleted', 'restored', 'purged', 'price_changed'));
+
+CREATE FUNCTION record_price_change_event() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO subscription_events (subscription_id, event_type, actor, price_change)
+    VALUES (NEW.subscription_id, 'price_changed', NEW.created_by, to_jsonb(NEW));
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER subscription_price_changes_record_event
+    AFTER INSERT ON subscription_price_changes
+    FOR EACH ROW EXECUTE FUNCTION record_price_change_event();
+
+-- Изменения, сохраненные до появления триггера
+INSERT INTO subscription_events (subscription_id, event_type, actor, occurred_at, price_change)
+SELECT pc.subscription_id, 'price_changed', pc.created_by, pc.created_at, to_jsonb(pc)
+FROM subscription_price_changes pc
+ORDER BY pc.created_at;
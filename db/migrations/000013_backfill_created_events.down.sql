@@ -0,0 +1,2 @@
+-- Журнал только дополняется, а восстановленные события не отличить от записанных триггером,
+-- поэтому откат их не удаляет
@@ -0,0 +1,8 @@
+-- Подписки, созданные до появления журнала и с тех пор не менявшиеся, не имеют в нем ни одного события,
+-- и их история отвечала бы 404. Им записывается событие создания с текущим состоянием подписки:
+-- каким оно было при создании, уже неизвестно
+INSERT INTO subscription_events (subscription_id, event_type, actor, occurred_at, after)
+SELECT s.id, 'created', s.created_by, s.created_at, to_jsonb(s)
+FROM subscriptions s
+WHERE NOT EXISTS (SELECT 1 FROM subscription_events e WHERE e.subscription_id = s.id)
+ORDER BY s.created_at, s.id;
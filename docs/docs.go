@@ -15,30 +15,194 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/subscriptions/": {
+        "/api/admin/exchange-rates": {
             "get": {
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "summary": "Получение списка курсов валют",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Фильтр по коду валюты (опционально)",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exchange rates ordered by currency and valid_from",
+                        "schema": {
+                            "$ref": "#/definitions/exchangerate.ListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid currency",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/exchange-rates/{currency}/{valid_from}": {
+            "put": {
+                "description": "Курс задает стоимость одной единицы валюты в рублях и действует до следующего установленного курса",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "summary": "Установка курса валюты к рублю, действующего с указанного месяца",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "USD",
+                        "description": "Currency code (ISO 4217)",
+                        "name": "currency",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "01-2025",
+                        "description": "Month from which the rate is effective in MM-YYYY format",
+                        "name": "valid_from",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Exchange rate",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/exchangerate.RateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exchange rate saved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/exchangerate.RateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON, currency, valid_from or rate outside 0.000001..999999999999",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "summary": "Удаление курса валюты, действующего с указанного месяца",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "USD",
+                        "description": "Currency code (ISO 4217)",
+                        "name": "currency",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "01-2025",
+                        "description": "Month from which the rate is effective in MM-YYYY format",
+                        "name": "valid_from",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Exchange rate deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid currency or valid_from",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Exchange rate not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/subscriptions/": {
+            "get": {
+                "consumes": [
                     "application/json"
                 ],
-                "summary": "Получение списка подписок с фильтрацией по ID пользователя, названием сервиса и пагинацией",
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "summary": "Получение списка подписок с фильтрацией, сортировкой и пагинацией",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Курсор из next_cursor предыдущей страницы (опционально)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "Номер страницы (опционально)",
+                        "description": "Номер страницы, устаревший режим; нельзя передавать вместе с cursor (опционально)",
                         "name": "page",
                         "in": "query"
                     },
                     {
                         "type": "integer",
                         "default": 20,
-                        "description": "Количество элементов на странице (опционально)",
+                        "description": "Количество элементов на странице, не больше 100 (опционально)",
                         "name": "limit",
                         "in": "query"
                     },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Вернуть total_count — общее число подписок по фильтру (опционально)",
+                        "name": "with_total",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Фильтр по ID пользователя (опционально)",
@@ -50,6 +214,91 @@ const docTemplate = `{
                         "description": "Фильтр по названию сервиса (опционально)",
                         "name": "service_name",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Регистронезависимый поиск по началу названия сервиса (опционально)",
+                        "name": "service_name_prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Минимальная цена, например 199.99 (опционально)",
+                        "name": "price_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Максимальная цена, например 999.99 (опционально)",
+                        "name": "price_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Подписка действует в месяце MM-YYYY (опционально)",
+                        "name": "active_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Подписка началась не раньше месяца MM-YYYY (опционально)",
+                        "name": "start_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Подписка началась не позже месяца MM-YYYY (опционально)",
+                        "name": "start_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Подписки, измененные начиная с момента в формате RFC 3339, например 2025-07-01T00:00:00Z (опционально)",
+                        "name": "updated_since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Вместе с мягко удаленными подписками, у них заполнено deleted_at (опционально)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "ended"
+                        ],
+                        "type": "string",
+                        "description": "Состояние подписки относительно текущего месяца (опционально)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "price",
+                            "start_date",
+                            "end_date",
+                            "service_name",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Поле сортировки (опционально)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Направление сортировки (опционально)",
+                        "name": "order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -60,21 +309,15 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid format for UUID in ` + "`" + `user_id` + "`" + `",
+                        "description": "Invalid filter, sort or pagination parameters",
                         "schema": {
-                            "$ref": "#/definitions/subscription.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Subscriptions not found",
-                        "schema": {
-                            "$ref": "#/definitions/subscription.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/subscription.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
@@ -84,7 +327,8 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "summary": "Создание новой подписки для пользователя",
                 "parameters": [
@@ -103,32 +347,40 @@ const docTemplate = `{
                         "description": "Subscription created successful",
                         "schema": {
                             "$ref": "#/definitions/subscription.SubResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the subscription for If-Match"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid JSON or Invalid format for UUID in ` + "`" + `user_id` + "`" + `",
+                        "description": "Invalid JSON, dates not in MM-YYYY format or validation failed with the list of invalid fields",
                         "schema": {
-                            "$ref": "#/definitions/subscription.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/subscription.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/subscriptions/summary/{user_id}/{service_name}": {
+        "/api/subscriptions/summary/{user_id}": {
             "get": {
+                "description": "Возвращает итог по каждому сервису и общий итог. Параметр service_name ограничивает расчет одним сервисом.\nБез end_date период считается до текущего месяца",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
-                "summary": "Рассчитывает общую стоимость подписки для пользователя за определенный период",
+                "summary": "Рассчитывает общую стоимость всех подписок пользователя за период с группировкой по сервисам",
                 "parameters": [
                     {
                         "type": "string",
@@ -139,10 +391,9 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Service name",
+                        "description": "Фильтр по названию сервиса (опционально)",
                         "name": "service_name",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "string",
@@ -158,141 +409,350 @@ const docTemplate = `{
                         "description": "End date in MM-YYYY format",
                         "name": "end_date",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "RUB",
+                        "description": "Валюта итогов (ISO 4217), по умолчанию RUB",
+                        "name": "currency",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success response with total cost",
+                        "description": "Success response with totals per service and grand total",
                         "schema": {
-                            "$ref": "#/definitions/subscription.Summary"
+                            "$ref": "#/definitions/subscription.UserSummary"
                         }
                     },
                     "400": {
-                        "description": "Invalid format for UUID in ` + "`" + `user_id` + "`" + `, empty service_name or missing start_date",
+                        "description": "Invalid UUID in ` + "`" + `user_id` + "`" + `, missing or invalid start_date/end_date or currency",
                         "schema": {
-                            "$ref": "#/definitions/subscription.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
-                    "404": {
-                        "description": "No subscriptions found for given criteria",
+                    "422": {
+                        "description": "No exchange rate for requested currency",
                         "schema": {
-                            "$ref": "#/definitions/subscription.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/subscription.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/subscriptions/{id}": {
+        "/api/subscriptions/summary/{user_id}/monthly": {
             "get": {
+                "description": "Возвращает по одной строке на каждый календарный месяц периода (включая месяцы без трат)\nс общей суммой и списком подписок, из которых она сложилась. Без end_date период считается до текущего месяца",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
-                "summary": "Получение подписки по ID",
+                "summary": "Помесячная разбивка стоимости подписок пользователя за период",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Subscription ID in UUID format",
-                        "name": "id",
+                        "description": "User ID in UUID format",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "01-2025",
+                        "description": "Start date in MM-YYYY format",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "12-2025",
+                        "description": "End date in MM-YYYY format",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "RUB",
+                        "description": "Валюта итогов (ISO 4217), по умолчанию RUB",
+                        "name": "currency",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Subscription details",
+                        "description": "Success response with monthly time series",
                         "schema": {
-                            "$ref": "#/definitions/subscription.SubResponse"
+                            "$ref": "#/definitions/subscription.MonthlyBreakdown"
                         }
                     },
                     "400": {
-                        "description": "Invalid format for UUID in ` + "`" + `id` + "`" + `",
+                        "description": "Invalid UUID in ` + "`" + `user_id` + "`" + `, missing or invalid start_date/end_date or currency",
                         "schema": {
-                            "$ref": "#/definitions/subscription.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Subscription not found",
+                    "422": {
+                        "description": "No exchange rate for requested currency",
                         "schema": {
-                            "$ref": "#/definitions/subscription.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/subscription.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/subscriptions/summary/{user_id}/{service_name}": {
+            "get": {
+                "description": "Стоимость считается по каждой подписке как количество списаний в периоде с учетом цикла оплаты\n(billing_period, billing_interval), умноженное на цену. Годовая подписка списывается раз в год в месяц начала.\nБессрочные подписки учитываются до конца периода, без end_date период считается до текущего месяца",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
-                "summary": "Обновление подписки по ID",
+                "summary": "Рассчитывает общую стоимость подписки для пользователя за определенный период",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Subscription ID in UUID format",
-                        "name": "id",
+                        "description": "User ID in UUID format",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Subscription update data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/subscription.SubRequest"
-                        }
+                        "type": "string",
+                        "description": "Service name",
+                        "name": "service_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "01-2025",
+                        "description": "Start date in MM-YYYY format",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "12-2025",
+                        "description": "End date in MM-YYYY format",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "RUB",
+                        "description": "Валюта итогов (ISO 4217), по умолчанию RUB",
+                        "name": "currency",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated subscription details",
+                        "description": "Success response with total cost, billed months and per-subscription breakdown",
+                        "schema": {
+                            "$ref": "#/definitions/subscription.Summary"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid UUID in ` + "`" + `user_id` + "`" + `, empty service_name, missing or invalid start_date/end_date or currency",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "No subscriptions found for given criteria",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "No exchange rate for requested currency",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/subscriptions/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "summary": "Получение подписки по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID in UUID format",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous response; 304 is returned if the subscription has not changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subscription details",
                         "schema": {
                             "$ref": "#/definitions/subscription.SubResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the subscription for If-Match and If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Subscription has not changed since the ETag in If-None-Match",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the subscription for If-Match and If-None-Match"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid format for UUID in ` + "`" + `id` + "`" + `, JSON, or validation error",
+                        "description": "Invalid format for UUID in ` + "`" + `id` + "`" + `",
                         "schema": {
-                            "$ref": "#/definitions/subscription.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
                         "description": "Subscription not found",
                         "schema": {
-                            "$ref": "#/definitions/subscription.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/subscription.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "summary": "Обновление подписки по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID in UUID format",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Subscription update data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/subscription.SubRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from Get; the request fails with 412 if the subscription has been modified since",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated subscription details",
+                        "schema": {
+                            "$ref": "#/definitions/subscription.SubResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the subscription"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON, dates not in MM-YYYY format or validation failed with the list of invalid fields (including ` + "`" + `id` + "`" + `)",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version of the subscription",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Price or currency change of a started subscription or currency change of a subscription with price changes; use the price-changes endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаление мягкое: подписку можно восстановить через POST /api/subscriptions/{id}/restore, пока она не очищена по сроку хранения.",
+                "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
                 "summary": "Удаление подписки по ID",
                 "parameters": [
                     {
@@ -301,6 +761,12 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from Get; the request fails with 412 if the subscription has been modified since",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -310,19 +776,379 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid format for UUID in ` + "`" + `id` + "`" + `",
                         "schema": {
-                            "$ref": "#/definitions/subscription.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version of the subscription",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Меняются только переданные поля. ` + "`" + `\"end_date\": null` + "`" + ` снимает дату окончания.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "summary": "Частичное обновление подписки по ID (JSON Merge Patch)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID in UUID format",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/subscription.PatchRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from Get; the request fails with 412 if the subscription has been modified since",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated subscription details",
+                        "schema": {
+                            "$ref": "#/definitions/subscription.SubResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the subscription"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON, unknown or null fields, or validation of the patched subscription failed",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version of the subscription",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Price or currency change of a started subscription or currency change of a subscription with price changes; use the price-changes endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/subscriptions/{id}/history": {
+            "get": {
+                "description": "События отдаются от новых к старым. Журнал сохраняется и после окончательного удаления подписки.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "summary": "Получение журнала изменений подписки по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID in UUID format",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор из next_cursor предыдущей страницы (опционально)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Количество событий на странице, не больше 100 (опционально)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of subscription change history",
+                        "schema": {
+                            "$ref": "#/definitions/subscription.HistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format for UUID in ` + "`" + `id` + "`" + ` or invalid pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription has no recorded changes",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/subscriptions/{id}/price-changes": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "summary": "Получение изменений цены подписки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID in UUID format",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price changes ordered by effective_from",
+                        "schema": {
+                            "$ref": "#/definitions/subscription.PriceChangesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format for UUID in ` + "`" + `id` + "`" + `",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Новая цена действует с effective_from до следующего изменения, расчеты за прошлые месяцы не меняются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "summary": "Изменение цены подписки с указанного месяца",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID in UUID format",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New price and the month it takes effect from",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/subscription.PriceChangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Price change created",
+                        "schema": {
+                            "$ref": "#/definitions/subscription.PriceChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON, effective_from not in MM-YYYY format or outside the subscription",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "404": {
                         "description": "Subscription not found",
                         "schema": {
-                            "$ref": "#/definitions/subscription.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Price change from this month already exists",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/subscriptions/{id}/restore": {
+            "post": {
+                "description": "Восстановление идемпотентно: для действующей подписки возвращается она сама без изменений.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "summary": "Восстановление удаленной подписки по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID in UUID format",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version from the list with include_deleted=true; the request fails with 412 if the subscription has been modified since",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored subscription details",
+                        "schema": {
+                            "$ref": "#/definitions/subscription.SubResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the subscription"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format for UUID in ` + "`" + `id` + "`" + `",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found or already purged",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version of the subscription",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/subscription.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Проверка, что процесс жив",
+                "responses": {
+                    "200": {
+                        "description": "Service is alive",
+                        "schema": {
+                            "$ref": "#/definitions/health.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Проверяет соединение с базой, версию миграций golang-migrate и статистику пула соединений.\nВо время graceful shutdown возвращает 503",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Проверка готовности принимать запросы",
+                "responses": {
+                    "200": {
+                        "description": "Service is ready",
+                        "schema": {
+                            "$ref": "#/definitions/health.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service is shutting down, database is unavailable or migration is dirty",
+                        "schema": {
+                            "$ref": "#/definitions/health.Response"
                         }
                     }
                 }
@@ -330,12 +1156,217 @@ const docTemplate = `{
         }
     },
     "definitions": {
-        "subscription.ErrorResponse": {
+        "exchangerate.ListResponse": {
+            "type": "object",
+            "properties": {
+                "rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/exchangerate.RateResponse"
+                    }
+                }
+            }
+        },
+        "exchangerate.RateRequest": {
+            "type": "object",
+            "required": [
+                "rate"
+            ],
+            "properties": {
+                "rate": {
+                    "type": "number",
+                    "example": 92.5
+                }
+            }
+        },
+        "exchangerate.RateResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "rate": {
+                    "type": "number",
+                    "example": 92.5
+                },
+                "valid_from": {
+                    "type": "string",
+                    "example": "01-2025"
+                }
+            }
+        },
+        "health.Migration": {
+            "type": "object",
+            "properties": {
+                "dirty": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "health.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "database is unavailable"
+                },
+                "migration": {
+                    "$ref": "#/definitions/health.Migration"
+                },
+                "pool": {
+                    "$ref": "#/definitions/internal_transport_http_dto_health.Pool"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ready"
+                }
+            }
+        },
+        "internal_transport_http_dto_health.Pool": {
+            "type": "object",
+            "properties": {
+                "acquire_count": {
+                    "type": "integer",
+                    "example": 1024
+                },
+                "acquired_conns": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "constructing_conns": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "empty_acquire_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "idle_conns": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "max_conns": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "total_conns": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "problem.InvalidParam": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "end_date"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "must not be before start_date"
+                }
+            }
+        },
+        "problem.Problem": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string",
+                    "example": "One or more parameters are invalid"
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/subscriptions/"
+                },
+                "invalid-params": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/problem.InvalidParam"
+                    }
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "4f1c2a9e-8d0b-4e55-9a7a-2f3c1d5e6b7a"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Validation failed"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "/problems/validation-error"
+                }
+            }
+        },
+        "subscription.EventResponse": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string",
+                    "example": "bob@example.com"
+                },
+                "after": {
+                    "$ref": "#/definitions/subscription.SubResponse"
+                },
+                "before": {
+                    "$ref": "#/definitions/subscription.SubResponse"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "occurred_at": {
+                    "type": "string",
+                    "example": "2025-07-15T09:30:00Z"
+                },
+                "price_change": {
+                    "$ref": "#/definitions/subscription.PriceChangeResponse"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "updated",
+                        "deleted",
+                        "restored",
+                        "purged",
+                        "price_changed"
+                    ],
+                    "example": "updated"
+                }
+            }
+        },
+        "subscription.HistoryResponse": {
             "type": "object",
             "properties": {
-                "message": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/subscription.EventResponse"
+                    }
+                },
+                "has_next": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
                     "type": "string",
-                    "example": "string"
+                    "example": "eyJrIjoiZXZlbnRfaWQiLCJvIjoiZGVzYyIsImlkIjoiNDIifQ"
                 }
             }
         },
@@ -350,6 +1381,10 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 20
                 },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJrIjoiaWQiLCJvIjoiYXNjIiwiaWQiOiI1NTBlODQwMC1lMjliLTQxZDQtYTcxNi00NDY2NTU0NDAwMDAifQ"
+                },
                 "page": {
                     "type": "integer",
                     "example": 1
@@ -359,6 +1394,202 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/subscription.SubResponse"
                     }
+                },
+                "total_count": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "subscription.MonthlyBreakdown": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "12-2025"
+                },
+                "months": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/subscription.MonthlyCost"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "01-2025"
+                },
+                "total_cost": {
+                    "type": "string",
+                    "example": "8400.00"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+                }
+            }
+        },
+        "subscription.MonthlyCost": {
+            "type": "object",
+            "properties": {
+                "month": {
+                    "type": "string",
+                    "example": "01-2025"
+                },
+                "subscriptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/subscription.MonthlyCostItem"
+                    }
+                },
+                "total_cost": {
+                    "type": "string",
+                    "example": "700.00"
+                }
+            }
+        },
+        "subscription.MonthlyCostItem": {
+            "type": "object",
+            "properties": {
+                "charges": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "cost": {
+                    "type": "string",
+                    "example": "400.00"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "Yandex Plus"
+                }
+            }
+        },
+        "subscription.PatchRequest": {
+            "type": "object",
+            "properties": {
+                "billing_interval": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "billing_period": {
+                    "type": "string",
+                    "enum": [
+                        "week",
+                        "month",
+                        "quarter",
+                        "year"
+                    ],
+                    "example": "month"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "end_date": {
+                    "type": "string",
+                    "x-nullable": true,
+                    "example": "12-2025"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "299.99"
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "Yandex Plus"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "07-2025"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+                }
+            }
+        },
+        "subscription.PriceChangeRequest": {
+            "type": "object",
+            "required": [
+                "effective_from",
+                "price"
+            ],
+            "properties": {
+                "effective_from": {
+                    "type": "string",
+                    "example": "04-2025"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "399.00"
+                }
+            }
+        },
+        "subscription.PriceChangeResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-03-20T10:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "alice@example.com"
+                },
+                "effective_from": {
+                    "type": "string",
+                    "example": "04-2025"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "399.00"
+                },
+                "subscription_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "subscription.PriceChangesResponse": {
+            "type": "object",
+            "properties": {
+                "price_changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/subscription.PriceChangeResponse"
+                    }
+                }
+            }
+        },
+        "subscription.ServiceSummary": {
+            "type": "object",
+            "properties": {
+                "months": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "Yandex Plus"
+                },
+                "subscriptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/subscription.SummaryItem"
+                    }
+                },
+                "total_cost": {
+                    "type": "string",
+                    "example": "4800.00"
                 }
             }
         },
@@ -371,14 +1602,35 @@ const docTemplate = `{
                 "user_id"
             ],
             "properties": {
+                "billing_interval": {
+                    "type": "integer",
+                    "default": 1,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "billing_period": {
+                    "type": "string",
+                    "default": "month",
+                    "enum": [
+                        "week",
+                        "month",
+                        "quarter",
+                        "year"
+                    ],
+                    "example": "month"
+                },
+                "currency": {
+                    "type": "string",
+                    "default": "RUB",
+                    "example": "RUB"
+                },
                 "end_date": {
                     "type": "string",
                     "example": "12-2025"
                 },
                 "price": {
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 400
+                    "type": "string",
+                    "example": "199.99"
                 },
                 "service_name": {
                     "type": "string",
@@ -397,6 +1649,32 @@ const docTemplate = `{
         "subscription.SubResponse": {
             "type": "object",
             "properties": {
+                "billing_interval": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "billing_period": {
+                    "type": "string",
+                    "example": "month"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-01T12:00:00Z"
+                },
+                "created_by": {
+                    "description": "Principals from the gateway header; omitted when the change was made by an anonymous request",
+                    "type": "string",
+                    "example": "alice@example.com"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "deleted_at": {
+                    "description": "Set only for soft-deleted subscriptions, which are returned by the list with include_deleted=true",
+                    "type": "string",
+                    "example": "2025-08-01T10:00:00Z"
+                },
                 "end_date": {
                     "type": "string",
                     "example": "12-2025"
@@ -406,8 +1684,8 @@ const docTemplate = `{
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
                 "price": {
-                    "type": "integer",
-                    "example": 400
+                    "type": "string",
+                    "example": "199.99"
                 },
                 "service_name": {
                     "type": "string",
@@ -417,19 +1695,40 @@ const docTemplate = `{
                     "type": "string",
                     "example": "07-2025"
                 },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-07-15T09:30:00Z"
+                },
+                "updated_by": {
+                    "type": "string",
+                    "example": "bob@example.com"
+                },
                 "user_id": {
                     "type": "string",
                     "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+                },
+                "version": {
+                    "description": "Version changes on every modification; the same value is returned in the ETag header",
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
         "subscription.Summary": {
             "type": "object",
             "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
                 "end_date": {
                     "type": "string",
                     "example": "12-2025"
                 },
+                "months": {
+                    "type": "integer",
+                    "example": 12
+                },
                 "service_name": {
                     "type": "string",
                     "example": "Yandex Plus"
@@ -438,9 +1737,99 @@ const docTemplate = `{
                     "type": "string",
                     "example": "01-2025"
                 },
+                "subscriptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/subscription.SummaryItem"
+                    }
+                },
                 "total_cost": {
+                    "type": "string",
+                    "example": "4800.00"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+                }
+            }
+        },
+        "subscription.SummaryItem": {
+            "type": "object",
+            "properties": {
+                "billing_interval": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "billing_period": {
+                    "type": "string",
+                    "example": "month"
+                },
+                "charges": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "cost": {
+                    "type": "string",
+                    "example": "4800.00"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "12-2025"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "months": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "price": {
+                    "type": "string",
+                    "example": "199.99"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "01-2025"
+                }
+            }
+        },
+        "subscription.UserSummary": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "12-2025"
+                },
+                "months": {
                     "type": "integer",
-                    "example": 800
+                    "example": 24
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "Yandex Plus"
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/subscription.ServiceSummary"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "01-2025"
+                },
+                "total_cost": {
+                    "type": "string",
+                    "example": "8400.00"
                 },
                 "user_id": {
                     "type": "string",
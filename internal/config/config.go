@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
@@ -13,6 +15,36 @@ type Config struct {
 
 	MinConns int32 `yaml:"POSTGRES_MIN_CONN" env:"POSTGRES_MIN_CONN"`
 	MaxConns int32 `yaml:"POSTGRES_MAX_CONN" env:"POSTGRES_MAX_CONN"`
+
+	// Уровень и формат логов: json для сборщика логов, console для локальной отладки
+	LogLevel  string `yaml:"LOG_LEVEL" env:"LOG_LEVEL" env-default:"info"`
+	LogFormat string `yaml:"LOG_FORMAT" env:"LOG_FORMAT" env-default:"json"`
+
+	// Сэмплирование: в секунду пишутся первые Initial одинаковых записей, затем каждая Thereafter-я. 0 отключает.
+	// Access-лог не сэмплируется никогда
+	LogSamplingInitial    int `yaml:"LOG_SAMPLING_INITIAL" env:"LOG_SAMPLING_INITIAL" env-default:"0"`
+	LogSamplingThereafter int `yaml:"LOG_SAMPLING_THEREAFTER" env:"LOG_SAMPLING_THEREAFTER" env-default:"100"`
+
+	// Файл для логов в дополнение к stdout, пустой путь отключает запись в файл
+	LogFile           string `yaml:"LOG_FILE" env:"LOG_FILE"`
+	LogFileMaxSizeMB  int    `yaml:"LOG_FILE_MAX_SIZE_MB" env:"LOG_FILE_MAX_SIZE_MB" env-default:"100"`
+	LogFileMaxBackups int    `yaml:"LOG_FILE_MAX_BACKUPS" env:"LOG_FILE_MAX_BACKUPS" env-default:"5"`
+	LogFileMaxAgeDays int    `yaml:"LOG_FILE_MAX_AGE_DAYS" env:"LOG_FILE_MAX_AGE_DAYS" env-default:"30"`
+	LogFileCompress   bool   `yaml:"LOG_FILE_COMPRESS" env:"LOG_FILE_COMPRESS" env-default:"true"`
+
+	// Экспорт трейсов: otlp — в коллектор по OTLP/HTTP, stdout — в консоль для локальной отладки, none — выключен
+	TracingExporter     string  `yaml:"TRACING_EXPORTER" env:"TRACING_EXPORTER" env-default:"none"`
+	TracingOTLPEndpoint string  `yaml:"TRACING_OTLP_ENDPOINT" env:"TRACING_OTLP_ENDPOINT" env-default:"localhost:4318"`
+	TracingServiceName  string  `yaml:"TRACING_SERVICE_NAME" env:"TRACING_SERVICE_NAME" env-default:"subscriptions"`
+	TracingSampleRatio  float64 `yaml:"TRACING_SAMPLE_RATIO" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+
+	// Заголовок, в котором шлюз передает аутентифицированного пользователя. Пишется в created_by и updated_by
+	PrincipalHeader string `yaml:"PRINCIPAL_HEADER" env:"PRINCIPAL_HEADER" env-default:"X-Authenticated-User"`
+
+	// Мягко удаленные подписки окончательно удаляются через SOFT_DELETE_RETENTION после удаления.
+	// Очистка запускается раз в PURGE_INTERVAL, 0 отключает ее
+	SoftDeleteRetention time.Duration `yaml:"SOFT_DELETE_RETENTION" env:"SOFT_DELETE_RETENTION" env-default:"720h"`
+	PurgeInterval       time.Duration `yaml:"PURGE_INTERVAL" env:"PURGE_INTERVAL" env-default:"1h"`
 }
 
 func New() (*Config, error) {
@@ -0,0 +1,13 @@
+package entity
+
+import "errors"
+
+// ErrNotFound — запрошенной записи нет. Репозиторий возвращает его вместо pgx.ErrNoRows и нулевого числа
+// затронутых строк, сервис передает дальше без изменений
+var ErrNotFound = errors.New("not found")
+
+// ErrVersionMismatch — запись существует, но ее версия отличается от ожидаемой: ее успели изменить
+var ErrVersionMismatch = errors.New("version mismatch")
+
+// ErrOutsideTerm — месяц не попадает в срок действия записи, к которой относится
+var ErrOutsideTerm = errors.New("outside subscription term")
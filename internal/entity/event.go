@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// SubscriptionEventType — вид изменения подписки в журнале
+type SubscriptionEventType string
+
+const (
+	EventCreated  SubscriptionEventType = "created"
+	EventUpdated  SubscriptionEventType = "updated"
+	EventDeleted  SubscriptionEventType = "deleted"
+	EventRestored SubscriptionEventType = "restored"
+	// EventPurged — подписку окончательно удалила очистка по сроку хранения
+	EventPurged SubscriptionEventType = "purged"
+	// EventPriceChanged — добавлено изменение цены с указанного месяца
+	EventPriceChanged SubscriptionEventType = "price_changed"
+)
+
+// SubscriptionEvent — запись журнала изменений подписки.
+// Before нет у создания, After — у окончательного удаления. У изменения цены нет ни того,
+// ни другого, вместо них заполнено PriceChange
+type SubscriptionEvent struct {
+	Id             int64
+	SubscriptionId string
+	Type           SubscriptionEventType
+	// Actor пуст у анонимных запросов и у очистки
+	Actor       *string
+	OccurredAt  time.Time
+	Before      *Subscription
+	After       *Subscription
+	PriceChange *PriceChange
+}
+
+// HistoryPage — параметры выборки журнала для репозитория: события старше BeforeId, от новых к старым
+type HistoryPage struct {
+	Limit    int
+	BeforeId int64
+}
+
+// SubscriptionHistory — страница журнала изменений подписки
+type SubscriptionHistory struct {
+	Events     []SubscriptionEvent
+	HasNext    bool
+	NextCursor string
+}
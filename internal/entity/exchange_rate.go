@@ -0,0 +1,28 @@
+package entity
+
+import "errors"
+
+// BaseCurrency — валюта, к которой привязаны все курсы
+const BaseCurrency = "RUB"
+
+var ErrExchangeRateNotFound = errors.New("exchange rate not found")
+
+type ExchangeRate struct {
+	Currency  string
+	ValidFrom YearMonth
+	Rate      Rate
+}
+
+func IsValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
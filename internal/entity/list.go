@@ -0,0 +1,107 @@
+package entity
+
+import "time"
+
+// SortOrder — направление сортировки списка
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+func (o SortOrder) IsValid() bool {
+	return o == SortAsc || o == SortDesc
+}
+
+// Ключи сортировки списка подписок. При равных значениях записи упорядочиваются по id
+const (
+	SortKeyId          = "id"
+	SortKeyPrice       = "price"
+	SortKeyStartDate   = "start_date"
+	SortKeyEndDate     = "end_date"
+	SortKeyServiceName = "service_name"
+	SortKeyUpdatedAt   = "updated_at"
+)
+
+func IsValidSortKey(key string) bool {
+	switch key {
+	case SortKeyId, SortKeyPrice, SortKeyStartDate, SortKeyEndDate, SortKeyServiceName, SortKeyUpdatedAt:
+		return true
+	}
+	return false
+}
+
+// Sort — порядок записей в списке
+type Sort struct {
+	Key   string
+	Order SortOrder
+}
+
+// SubscriptionStatus — состояние подписки относительно текущего месяца
+type SubscriptionStatus string
+
+const (
+	// StatusActive — подписка без даты окончания или заканчивается не раньше текущего месяца
+	StatusActive SubscriptionStatus = "active"
+	// StatusEnded — подписка закончилась до текущего месяца
+	StatusEnded SubscriptionStatus = "ended"
+)
+
+func (s SubscriptionStatus) IsValid() bool {
+	return s == StatusActive || s == StatusEnded
+}
+
+// SubscriptionFilter — условия отбора подписок в списке. Пустые поля не ограничивают выборку
+type SubscriptionFilter struct {
+	UserId      string
+	ServiceName string
+	// Регистронезависимый поиск по началу названия сервиса
+	ServiceNamePrefix string
+	PriceMin          *Amount
+	PriceMax          *Amount
+	// Подписка действует в этом месяце: началась не позже и закончилась не раньше
+	ActiveAt  *YearMonth
+	StartFrom *YearMonth
+	StartTo   *YearMonth
+	Status    SubscriptionStatus
+	// Подписки, измененные начиная с этого момента, — для инкрементальной синхронизации
+	UpdatedSince *time.Time
+	// Вместе с мягко удаленными подписками
+	IncludeDeleted bool
+}
+
+// Cursor — позиция в списке для keyset-пагинации.
+// Хранит ключ и направление сортировки, с которыми строилась страница, значение ключа и id последней записи
+type Cursor struct {
+	SortKey   string    `json:"k"`
+	Order     SortOrder `json:"o"`
+	LastValue string    `json:"v,omitempty"`
+	LastId    string    `json:"id"`
+}
+
+// Pagination — параметры страницы от клиента. Page задает устаревший режим со смещением,
+// Cursor — непрозрачный курсор из next_cursor предыдущей страницы. Без обоих возвращается первая страница
+type Pagination struct {
+	Page   int
+	Limit  int
+	Cursor string
+	// WithTotal запрашивает общее число записей по фильтру — это отдельный запрос count(*)
+	WithTotal bool
+}
+
+// ListPage — параметры выборки для репозитория: After для keyset-пагинации или Offset для устаревшего режима
+type ListPage struct {
+	Limit  int
+	Offset int
+	After  *Cursor
+}
+
+// SubscriptionList — страница списка подписок
+type SubscriptionList struct {
+	Subscriptions []Subscription
+	HasNext       bool
+	NextCursor    string
+	// TotalCount заполняется только при Pagination.WithTotal
+	TotalCount *int
+}
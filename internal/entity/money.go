@@ -0,0 +1,125 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// minorUnits — количество минимальных единиц (копеек, центов) в единице валюты
+const minorUnits = 100
+
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// Amount — денежная сумма в минимальных единицах валюты
+type Amount int64
+
+// Money — денежная сумма в минимальных единицах вместе с кодом валюты
+type Money struct {
+	Amount   Amount
+	Currency string
+}
+
+// ParseAmount разбирает десятичную запись суммы вида "199.99", не более двух знаков после точки
+func ParseAmount(s string) (Amount, error) {
+	digits := strings.TrimPrefix(s, "-")
+	negative := len(digits) != len(s)
+
+	whole, frac, hasFrac := strings.Cut(digits, ".")
+	if whole == "" || (hasFrac && (frac == "" || len(frac) > 2)) ||
+		!isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > (1<<63-1)/minorUnits-1 {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, s)
+	}
+
+	minor := 0
+	for i := 0; i < 2; i++ {
+		minor *= 10
+		if i < len(frac) {
+			minor += int(frac[i] - '0')
+		}
+	}
+
+	amount := Amount(units*minorUnits + int64(minor))
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String возвращает десятичную запись суммы с двумя знаками после точки
+func (a Amount) String() string {
+	sign := ""
+	value := int64(a)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, value/minorUnits, value%minorUnits)
+}
+
+// MarshalJSON кодирует сумму десятичной строкой, чтобы не терять точность на клиенте
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON принимает сумму как десятичной строкой ("199.99"), так и числом (199.99)
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(bytes.TrimSpace(data))
+
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+
+	amount, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+
+	*a = amount
+	return nil
+}
+
+func (a Amount) Int64Value() (pgtype.Int8, error) {
+	return pgtype.Int8{Int64: int64(a), Valid: true}, nil
+}
+
+func (a *Amount) ScanInt64(v pgtype.Int8) error {
+	if !v.Valid {
+		return fmt.Errorf("%w: NULL", ErrInvalidAmount)
+	}
+
+	*a = Amount(v.Int64)
+	return nil
+}
+
+// Times возвращает сумму n списаний
+func (m Money) Times(n int) Money {
+	return Money{Amount: m.Amount * Amount(n), Currency: m.Currency}
+}
+
+func (m Money) String() string {
+	return m.Amount.String() + " " + m.Currency
+}
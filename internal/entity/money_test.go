@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Amount
+	}{
+		{"0", 0},
+		{"400", 40000},
+		{"199.99", 19999},
+		{"199.9", 19990},
+		{"0.05", 5},
+		{"-12.50", -1250},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			amount, err := ParseAmount(tt.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, amount)
+		})
+	}
+}
+
+func TestParseAmount_Invalid(t *testing.T) {
+	for _, input := range []string{"", ".", "1.", ".5", "1.999", "1e3", "12,50", "abc", " 1", "99999999999999999999"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseAmount(input)
+
+			assert.ErrorIs(t, err, ErrInvalidAmount)
+		})
+	}
+}
+
+func TestAmount_String(t *testing.T) {
+	assert.Equal(t, "199.99", Amount(19999).String())
+	assert.Equal(t, "400.00", Amount(40000).String())
+	assert.Equal(t, "0.05", Amount(5).String())
+	assert.Equal(t, "-12.50", Amount(-1250).String())
+}
+
+func TestAmount_JSON(t *testing.T) {
+	var req struct {
+		Number Amount `json:"number"`
+		String Amount `json:"string"`
+	}
+
+	err := json.Unmarshal([]byte(`{"number": 199.99, "string": "400"}`), &req)
+
+	require.NoError(t, err)
+	assert.Equal(t, Amount(19999), req.Number)
+	assert.Equal(t, Amount(40000), req.String)
+
+	data, err := json.Marshal(req)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"number": "199.99", "string": "400.00"}`, string(data))
+}
+
+func TestAmount_JSON_Invalid(t *testing.T) {
+	var amount Amount
+
+	assert.Error(t, json.Unmarshal([]byte(`199.999`), &amount))
+	assert.Error(t, json.Unmarshal([]byte(`"abc"`), &amount))
+	assert.Error(t, json.Unmarshal([]byte(`null`), &amount))
+}
+
+func TestMoney_Times(t *testing.T) {
+	price := Money{Amount: 39900, Currency: "RUB"}
+
+	assert.Equal(t, Money{Amount: 119700, Currency: "RUB"}, price.Times(3))
+	assert.Equal(t, "1197.00 RUB", price.Times(3).String())
+}
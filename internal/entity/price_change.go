@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// PriceChange — новая цена подписки, действующая с месяца EffectiveFrom до следующего изменения.
+// До первого изменения действует цена подписки, валюта у изменения та же, что у подписки
+type PriceChange struct {
+	SubscriptionId string
+	EffectiveFrom  YearMonth
+	Price          Amount
+	CreatedAt      time.Time
+	// CreatedBy пуст у анонимных запросов
+	CreatedBy *string
+}
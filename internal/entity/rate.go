@@ -0,0 +1,162 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// rateDigits — знаков после точки в курсе, столько же хранит колонка NUMERIC(18, 6)
+const rateDigits = 6
+
+// rateScale — количество миллионных долей в единице курса
+const rateScale = 1_000_000
+
+// BaseRate — курс базовой валюты к самой себе
+const BaseRate Rate = rateScale
+
+var ErrInvalidRate = errors.New("invalid exchange rate")
+
+// Rate — курс валюты в миллионных долях рубля. Курс хранится точно, без двоичной дроби
+type Rate int64
+
+// ParseRate разбирает десятичную запись курса вида "92.5", не более шести знаков после точки
+func ParseRate(s string) (Rate, error) {
+	digits := strings.TrimPrefix(s, "-")
+	negative := len(digits) != len(s)
+
+	whole, frac, hasFrac := strings.Cut(digits, ".")
+	if whole == "" || (hasFrac && (frac == "" || len(frac) > rateDigits)) ||
+		!isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidRate, s)
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > (1<<63-1)/rateScale-1 {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidRate, s)
+	}
+
+	var micro int64
+	for i := 0; i < rateDigits; i++ {
+		micro *= 10
+		if i < len(frac) {
+			micro += int64(frac[i] - '0')
+		}
+	}
+
+	rate := Rate(units*rateScale + micro)
+	if negative {
+		rate = -rate
+	}
+
+	return rate, nil
+}
+
+// String возвращает десятичную запись курса без лишних нулей после точки: "92.5", "80"
+func (r Rate) String() string {
+	sign := ""
+	value := int64(r)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	s := fmt.Sprintf("%s%d", sign, value/rateScale)
+	if frac := value % rateScale; frac != 0 {
+		s += "." + strings.TrimRight(fmt.Sprintf("%06d", frac), "0")
+	}
+
+	return s
+}
+
+// MarshalJSON кодирует курс числом в точной десятичной записи
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalJSON принимает курс как числом (92.5), так и десятичной строкой ("92.5")
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	s := string(bytes.TrimSpace(data))
+
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+
+	rate, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+
+	*r = rate
+	return nil
+}
+
+func (r Rate) NumericValue() (pgtype.Numeric, error) {
+	return pgtype.Numeric{Int: big.NewInt(int64(r)), Exp: -rateDigits, Valid: true}, nil
+}
+
+func (r *Rate) ScanNumeric(v pgtype.Numeric) error {
+	if !v.Valid || v.NaN || v.InfinityModifier != pgtype.Finite {
+		return fmt.Errorf("%w: %v", ErrInvalidRate, v)
+	}
+
+	// Значение равно Int * 10^Exp, в миллионных долях — Int * 10^(Exp+6)
+	micro := new(big.Int).Set(v.Int)
+	shift := int64(v.Exp) + rateDigits
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(abs(shift)), nil)
+
+	if shift >= 0 {
+		micro.Mul(micro, pow)
+	} else {
+		rem := new(big.Int)
+		micro.QuoRem(micro, pow, rem)
+		if rem.Sign() != 0 {
+			return fmt.Errorf("%w: more than %d decimal places", ErrInvalidRate, rateDigits)
+		}
+	}
+
+	if !micro.IsInt64() {
+		return fmt.Errorf("%w: out of range", ErrInvalidRate)
+	}
+
+	*r = Rate(micro.Int64())
+	return nil
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Convert переводит сумму из валюты с курсом from в валюту с курсом to. Вычисление целочисленное,
+// результат округляется один раз до минимальной единицы, половина — от нуля
+func (a Amount) Convert(from, to Rate) (Amount, error) {
+	if to <= 0 {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidRate, to)
+	}
+
+	num := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(from)))
+	den := big.NewInt(int64(to))
+
+	// QuoRem отбрасывает дробную часть, остаток не меньше половины делителя округляет частное от нуля
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if new(big.Int).Lsh(rem.Abs(rem), 1).Cmp(den) >= 0 {
+		quo.Add(quo, big.NewInt(int64(num.Sign())))
+	}
+
+	if !quo.IsInt64() {
+		return 0, fmt.Errorf("%w: converted amount is out of range", ErrInvalidAmount)
+	}
+
+	return Amount(quo.Int64()), nil
+}
@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Rate
+	}{
+		{"1", 1_000_000},
+		{"92.5", 92_500_000},
+		{"0.000001", 1},
+		{"999999999999.999999", 999_999_999_999_999_999},
+		{"-1.5", -1_500_000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			rate, err := ParseRate(tt.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rate)
+			assert.Equal(t, tt.input, rate.String())
+		})
+	}
+}
+
+func TestParseRate_Invalid(t *testing.T) {
+	for _, input := range []string{"", ".", "1.", ".5", "0.0000001", "1e3", "9,25", "99999999999999"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseRate(input)
+
+			assert.ErrorIs(t, err, ErrInvalidRate)
+		})
+	}
+}
+
+func TestRate_JSON(t *testing.T) {
+	var req struct {
+		Number Rate `json:"number"`
+		String Rate `json:"string"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"number": 92.5, "string": "0.123456"}`), &req))
+	assert.Equal(t, Rate(92_500_000), req.Number)
+	assert.Equal(t, Rate(123_456), req.String)
+
+	// Курс остается числом, но в точной десятичной записи
+	data, err := json.Marshal(req)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"number": 92.5, "string": 0.123456}`, string(data))
+}
+
+func TestRate_Numeric(t *testing.T) {
+	m := pgtype.NewMap()
+
+	buf, err := m.Encode(pgtype.NumericOID, pgtype.TextFormatCode, Rate(92_500_001), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "92.500001", string(buf))
+
+	var rate Rate
+	require.NoError(t, m.Scan(pgtype.NumericOID, pgtype.TextFormatCode, []byte("80.120000"), &rate))
+	assert.Equal(t, Rate(80_120_000), rate)
+
+	// Значение с лишними знаками нельзя сохранить точно
+	err = m.Scan(pgtype.NumericOID, pgtype.TextFormatCode, []byte("1.0000001"), &rate)
+	assert.ErrorIs(t, err, ErrInvalidRate)
+}
+
+func TestAmount_Convert(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   Amount
+		from, to Rate
+		expected Amount
+	}{
+		{"to base", 1000, 92_500_000, BaseRate, 92_500},
+		{"from base rounds down", 399, BaseRate, 80_000_000, 5},
+		{"half rounds away from zero", 1, 500_000, BaseRate, 1},
+		{"below half rounds down", 1, 499_999, BaseRate, 0},
+		{"negative half", -1, 500_000, BaseRate, -1},
+		// В float64 произведение 10^15 * 1.000001 уже теряет младшие разряды
+		{"large amount stays exact", 1_000_000_000_000_000, 1_000_001, BaseRate, 1_000_001_000_000_000},
+		{"cross rate", 10, 110_000_000, 80_000_000, 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted, err := tt.amount.Convert(tt.from, tt.to)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, converted)
+		})
+	}
+}
+
+func TestAmount_Convert_OutOfRange(t *testing.T) {
+	_, err := Amount(1<<62).Convert(1000*BaseRate, BaseRate)
+
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+}
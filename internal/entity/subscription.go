@@ -1,10 +1,94 @@
 package entity
 
+import "time"
+
+type BillingPeriod string
+
+const (
+	BillingPeriodWeek    BillingPeriod = "week"
+	BillingPeriodMonth   BillingPeriod = "month"
+	BillingPeriodQuarter BillingPeriod = "quarter"
+	BillingPeriodYear    BillingPeriod = "year"
+)
+
+func (p BillingPeriod) IsValid() bool {
+	switch p {
+	case BillingPeriodWeek, BillingPeriodMonth, BillingPeriodQuarter, BillingPeriodYear:
+		return true
+	}
+	return false
+}
+
 type Subscription struct {
-	Id        string
-	Name      string
-	Price     int
-	UserId    string
-	StartDate string
-	EndDate   string
+	Id              string
+	Name            string
+	Price           Money
+	UserId          string
+	StartDate       YearMonth
+	EndDate         *YearMonth
+	BillingPeriod   BillingPeriod
+	BillingInterval int
+	// Version увеличивается при каждом изменении подписки. При обновлении — версия, которую видел клиент:
+	// запись проходит, только если она не изменилась, 0 отключает проверку
+	Version int64
+	// Заполняются репозиторием: время создания и последнего изменения, их автор. Автора нет у анонимных запросов
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	CreatedBy *string
+	UpdatedBy *string
+	// DeletedAt заполнен у мягко удаленной подписки, ее можно восстановить до очистки
+	DeletedAt *time.Time
+}
+
+// SubscriptionPatch — частичное обновление подписки. nil-поля не меняются
+type SubscriptionPatch struct {
+	Name      *string
+	Price     *Amount
+	Currency  *string
+	UserId    *string
+	StartDate *YearMonth
+	EndDate   *YearMonth
+	// ClearEndDate снимает дату окончания, делая подписку бессрочной
+	ClearEndDate    bool
+	BillingPeriod   *BillingPeriod
+	BillingInterval *int
+}
+
+// IsEmpty сообщает, что патч ничего не меняет
+func (p *SubscriptionPatch) IsEmpty() bool {
+	return *p == SubscriptionPatch{}
+}
+
+// Apply применяет патч к копии подписки и возвращает результат
+func (p *SubscriptionPatch) Apply(sub Subscription) Subscription {
+	if p.Name != nil {
+		sub.Name = *p.Name
+	}
+	if p.Price != nil {
+		sub.Price.Amount = *p.Price
+	}
+	if p.Currency != nil {
+		sub.Price.Currency = *p.Currency
+	}
+	if p.UserId != nil {
+		sub.UserId = *p.UserId
+	}
+	if p.StartDate != nil {
+		sub.StartDate = *p.StartDate
+	}
+	if p.EndDate != nil {
+		endDate := *p.EndDate
+		sub.EndDate = &endDate
+	}
+	if p.ClearEndDate {
+		sub.EndDate = nil
+	}
+	if p.BillingPeriod != nil {
+		sub.BillingPeriod = *p.BillingPeriod
+	}
+	if p.BillingInterval != nil {
+		sub.BillingInterval = *p.BillingInterval
+	}
+
+	return sub
 }
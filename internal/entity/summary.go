@@ -0,0 +1,39 @@
+package entity
+
+type Summary struct {
+	TotalCost     Money
+	Months        int
+	Subscriptions []SummaryItem
+}
+
+type SummaryItem struct {
+	Subscription Subscription
+	Months       int
+	Charges      int
+	Cost         Money
+}
+
+type UserSummary struct {
+	TotalCost Money
+	Months    int
+	Services  []ServiceSummary
+}
+
+type ServiceSummary struct {
+	ServiceName   string
+	TotalCost     Money
+	Months        int
+	Subscriptions []SummaryItem
+}
+
+type MonthlyCost struct {
+	Month         YearMonth
+	TotalCost     Money
+	Subscriptions []MonthlyCostItem
+}
+
+type MonthlyCostItem struct {
+	Subscription Subscription
+	Charges      int
+	Cost         Money
+}
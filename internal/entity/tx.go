@@ -0,0 +1,16 @@
+package entity
+
+// IsolationLevel — уровень изоляции транзакции PostgreSQL
+type IsolationLevel string
+
+const (
+	ReadCommitted  IsolationLevel = "read committed"
+	RepeatableRead IsolationLevel = "repeatable read"
+	Serializable   IsolationLevel = "serializable"
+)
+
+// TxOptions — параметры транзакции репозитория. Пустой уровень изоляции означает уровень по умолчанию (read committed)
+type TxOptions struct {
+	Isolation IsolationLevel
+	ReadOnly  bool
+}
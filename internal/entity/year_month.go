@@ -0,0 +1,125 @@
+package entity
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrInvalidYearMonth = errors.New("invalid date, expected MM-YYYY")
+
+// YearMonth — календарный месяц, в котором начинается или заканчивается подписка.
+// В JSON и query-параметрах записывается как MM-YYYY, в БД хранится как DATE первого числа месяца
+type YearMonth struct {
+	Year  int
+	Month time.Month
+}
+
+// ParseYearMonth строго разбирает дату в формате MM-YYYY
+func ParseYearMonth(s string) (YearMonth, error) {
+	if len(s) != 7 || s[2] != '-' || !isDigits(s[:2]) || !isDigits(s[3:]) {
+		return YearMonth{}, fmt.Errorf("%w: %q", ErrInvalidYearMonth, s)
+	}
+
+	month := int(s[0]-'0')*10 + int(s[1]-'0')
+	year := int(s[3]-'0')*1000 + int(s[4]-'0')*100 + int(s[5]-'0')*10 + int(s[6]-'0')
+
+	if month < 1 || month > 12 || year < 1 {
+		return YearMonth{}, fmt.Errorf("%w: %q", ErrInvalidYearMonth, s)
+	}
+
+	return YearMonth{Year: year, Month: time.Month(month)}, nil
+}
+
+// YearMonthOf возвращает месяц, в который попадает момент времени t
+func YearMonthOf(t time.Time) YearMonth {
+	return YearMonth{Year: t.Year(), Month: t.Month()}
+}
+
+// CurrentYearMonth возвращает текущий календарный месяц
+func CurrentYearMonth() YearMonth {
+	return YearMonthOf(time.Now())
+}
+
+func (ym YearMonth) IsZero() bool {
+	return ym == YearMonth{}
+}
+
+// Time возвращает первое число месяца в UTC
+func (ym YearMonth) Time() time.Time {
+	return time.Date(ym.Year, ym.Month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// AddMonths возвращает месяц, отстоящий на n месяцев вперед (или назад при отрицательном n)
+func (ym YearMonth) AddMonths(n int) YearMonth {
+	return YearMonthOf(ym.Time().AddDate(0, n, 0))
+}
+
+// Compare возвращает -1, 0 или 1, если ym раньше, совпадает или позже other
+func (ym YearMonth) Compare(other YearMonth) int {
+	if ym.Year != other.Year {
+		return cmp.Compare(ym.Year, other.Year)
+	}
+
+	return cmp.Compare(ym.Month, other.Month)
+}
+
+func (ym YearMonth) Before(other YearMonth) bool {
+	return ym.Compare(other) < 0
+}
+
+func (ym YearMonth) After(other YearMonth) bool {
+	return ym.Compare(other) > 0
+}
+
+func (ym YearMonth) String() string {
+	return fmt.Sprintf("%02d-%04d", int(ym.Month), ym.Year)
+}
+
+func (ym YearMonth) MarshalJSON() ([]byte, error) {
+	if ym.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(ym.String())
+}
+
+func (ym *YearMonth) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidYearMonth, data)
+	}
+
+	parsed, err := ParseYearMonth(s)
+	if err != nil {
+		return err
+	}
+
+	*ym = parsed
+	return nil
+}
+
+func (ym YearMonth) DateValue() (pgtype.Date, error) {
+	if ym.IsZero() {
+		return pgtype.Date{}, nil
+	}
+
+	return pgtype.Date{Time: ym.Time(), Valid: true}, nil
+}
+
+func (ym *YearMonth) ScanDate(v pgtype.Date) error {
+	if !v.Valid || v.InfinityModifier != pgtype.Finite {
+		return fmt.Errorf("%w: cannot scan %v", ErrInvalidYearMonth, v)
+	}
+
+	*ym = YearMonthOf(v.Time)
+	return nil
+}
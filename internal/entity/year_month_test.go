@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseYearMonth(t *testing.T) {
+	ym, err := ParseYearMonth("07-2025")
+
+	require.NoError(t, err)
+	assert.Equal(t, YearMonth{Year: 2025, Month: time.July}, ym)
+	assert.Equal(t, "07-2025", ym.String())
+}
+
+func TestParseYearMonth_Invalid(t *testing.T) {
+	for _, input := range []string{"", "7-2025", "00-2025", "13-2025", "07-25", "2025-07", "2025-07-01", "07/2025", "07-0000", "ab-2025", " 07-2025"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseYearMonth(input)
+
+			assert.ErrorIs(t, err, ErrInvalidYearMonth)
+		})
+	}
+}
+
+func TestYearMonth_Compare(t *testing.T) {
+	jan := YearMonth{Year: 2025, Month: time.January}
+	dec := YearMonth{Year: 2024, Month: time.December}
+
+	assert.True(t, dec.Before(jan))
+	assert.True(t, jan.After(dec))
+	assert.Equal(t, 0, jan.Compare(jan))
+	assert.Equal(t, jan, dec.AddMonths(1))
+	assert.Equal(t, dec, jan.AddMonths(-1))
+}
+
+func TestYearMonth_JSON(t *testing.T) {
+	var req struct {
+		StartDate YearMonth  `json:"start_date"`
+		EndDate   *YearMonth `json:"end_date,omitempty"`
+	}
+
+	err := json.Unmarshal([]byte(`{"start_date": "01-2025", "end_date": null}`), &req)
+
+	require.NoError(t, err)
+	assert.Equal(t, YearMonth{Year: 2025, Month: time.January}, req.StartDate)
+	assert.Nil(t, req.EndDate)
+
+	data, err := json.Marshal(req)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"start_date": "01-2025"}`, string(data))
+}
+
+func TestYearMonth_JSON_Invalid(t *testing.T) {
+	var ym YearMonth
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"13-2025"`), &ym), ErrInvalidYearMonth)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"2025-01-01"`), &ym), ErrInvalidYearMonth)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`202501`), &ym), ErrInvalidYearMonth)
+}
+
+func TestYearMonth_Date(t *testing.T) {
+	ym := YearMonth{Year: 2025, Month: time.March}
+
+	date, err := ym.DateValue()
+
+	require.NoError(t, err)
+	assert.Equal(t, pgtype.Date{Time: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), Valid: true}, date)
+
+	var scanned YearMonth
+	require.NoError(t, scanned.ScanDate(pgtype.Date{Time: time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC), Valid: true}))
+	assert.Equal(t, ym, scanned)
+
+	assert.Error(t, scanned.ScanDate(pgtype.Date{}))
+}
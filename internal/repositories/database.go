@@ -3,6 +3,7 @@ package repositories
 
 import (
 	"context"
+	"subscriptions/internal/entity"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -11,28 +12,43 @@ import (
 
 
 //go:generate mockgen -destination=mocks/row_mock.go -package=mocks github.com/jackc/pgx/v5 Row
+//go:generate mockgen -destination=mocks/rows_mock.go -package=mocks github.com/jackc/pgx/v5 Rows
+//go:generate mockgen -destination=mocks/tx_mock.go -package=mocks github.com/jackc/pgx/v5 Tx
 type DB interface {
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
 	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
 	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	// WithTx выполняет fn в одной транзакции: все запросы с контекстом, переданным в fn, попадают в нее.
+	// Ошибка fn откатывает транзакцию, nil — фиксирует
+	WithTx(ctx context.Context, opts entity.TxOptions, fn func(ctx context.Context) error) error
+}
+
+// Pool — пул соединений под PgxPoolAdapter, его реализует *pgxpool.Pool
+type Pool interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
 }
 
 type PgxPoolAdapter struct {
-	pool *pgxpool.Pool
+	pool Pool
 }
 
 func NewPgxPoolAdapter(pool *pgxpool.Pool) *PgxPoolAdapter {
 	return &PgxPoolAdapter{pool: pool}
 }
 
+// Запросы внутри WithTx выполняются в транзакции из контекста, остальные — на соединениях пула
+
 func (a *PgxPoolAdapter) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
-	return a.pool.QueryRow(ctx, sql, args...)
+	return a.conn(ctx).QueryRow(ctx, sql, args...)
 }
 
 func (a *PgxPoolAdapter) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
-	return a.pool.Exec(ctx, sql, args...)
+	return a.conn(ctx).Exec(ctx, sql, args...)
 }
 
 func (a *PgxPoolAdapter) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
-	return a.pool.Query(ctx, sql, args...)
+	return a.conn(ctx).Query(ctx, sql, args...)
 }
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"subscriptions/internal/entity"
+)
+
+func (r *subRepository) DeleteExchangeRate(ctx context.Context, currency string, validFrom entity.YearMonth) error {
+	tag, err := r.db.Exec(
+		ctx,
+		`DELETE FROM exchange_rates
+		WHERE currency = $1 AND valid_from = $2`,
+		currency,
+		validFrom,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to DELETE exchange rate: %v", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("exchange rate %s from %s: %w", currency, validFrom, entity.ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSubRepository_DeleteExchangeRate_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+
+	mockDB.EXPECT().
+		Exec(ctx, gomock.Any(), "USD", yearMonth("03-2025")).
+		Return(pgconn.NewCommandTag("DELETE 1"), nil)
+
+	err := repo.DeleteExchangeRate(ctx, "USD", yearMonth("03-2025"))
+
+	assert.NoError(t, err)
+}
+
+func TestSubRepository_DeleteExchangeRate_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+
+	mockDB.EXPECT().
+		Exec(ctx, gomock.Any(), "USD", yearMonth("03-2025")).
+		Return(pgconn.NewCommandTag("DELETE 0"), nil)
+
+	err := repo.DeleteExchangeRate(ctx, "USD", yearMonth("03-2025"))
+
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, entity.ErrNotFound))
+}
+
+func TestSubRepository_DeleteExchangeRate_DBError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+
+	mockDB.EXPECT().
+		Exec(ctx, gomock.Any(), "USD", yearMonth("03-2025")).
+		Return(pgconn.NewCommandTag(""), assert.AnError)
+
+	err := repo.DeleteExchangeRate(ctx, "USD", yearMonth("03-2025"))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to DELETE exchange rate")
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"subscriptions/internal/entity"
+)
+
+func (r *subRepository) GetExchangeRates(ctx context.Context, currency string) ([]entity.ExchangeRate, error) {
+	query := `
+		SELECT currency, valid_from, rate
+		FROM exchange_rates
+	`
+
+	args := []interface{}{}
+
+	if currency != "" {
+		query += " WHERE currency = $1"
+		args = append(args, currency)
+	}
+
+	query += " ORDER BY currency, valid_from"
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET exchange rates: %w", err)
+	}
+	defer rows.Close()
+
+	rates := []entity.ExchangeRate{}
+	for rows.Next() {
+		var rate entity.ExchangeRate
+
+		if err := rows.Scan(&rate.Currency, &rate.ValidFrom, &rate.Rate); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		rates = append(rates, rate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to GET exchange rates: %w", err)
+	}
+
+	return rates, nil
+}
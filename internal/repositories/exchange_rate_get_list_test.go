@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSubRepository_GetExchangeRates_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+
+	mockDB.EXPECT().
+		Query(ctx, gomock.Any(), "EUR").
+		Return(mockRows, nil)
+
+	gomock.InOrder(
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().
+			Scan(gomock.Any()).
+			DoAndReturn(func(dest ...interface{}) error {
+				*(dest[0].(*string)) = "EUR"
+				*(dest[1].(*entity.YearMonth)) = yearMonth("01-2025")
+				*(dest[2].(*entity.Rate)) = 105_250_000
+				return nil
+			}),
+		mockRows.EXPECT().Next().Return(false),
+	)
+	mockRows.EXPECT().Err().Return(nil)
+	mockRows.EXPECT().Close()
+
+	rates, err := repo.GetExchangeRates(ctx, "EUR")
+
+	require.NoError(t, err)
+	assert.Equal(t, []entity.ExchangeRate{{Currency: "EUR", ValidFrom: yearMonth("01-2025"), Rate: 105_250_000}}, rates)
+}
+
+func TestSubRepository_GetExchangeRates_QueryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+
+	mockDB.EXPECT().
+		Query(ctx, gomock.Any()).
+		Return(nil, assert.AnError)
+
+	rates, err := repo.GetExchangeRates(ctx, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to GET exchange rates")
+	assert.Nil(t, rates)
+}
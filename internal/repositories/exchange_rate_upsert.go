@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"subscriptions/internal/entity"
+)
+
+func (r *subRepository) UpsertExchangeRate(ctx context.Context, rate *entity.ExchangeRate) (*entity.ExchangeRate, error) {
+	var out entity.ExchangeRate
+
+	err := r.db.QueryRow(
+		ctx,
+		`INSERT INTO exchange_rates (currency, valid_from, rate)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (currency, valid_from) DO UPDATE SET rate = EXCLUDED.rate
+		RETURNING currency, valid_from, rate`,
+		rate.Currency,
+		rate.ValidFrom,
+		rate.Rate,
+	).Scan(&out.Currency, &out.ValidFrom, &out.Rate)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to UPSERT exchange rate: %v", err)
+	}
+
+	return &out, nil
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSubRepository_UpsertExchangeRate_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	rate := &entity.ExchangeRate{Currency: "USD", ValidFrom: yearMonth("03-2025"), Rate: 82_500_000}
+
+	mockDB.EXPECT().
+		QueryRow(
+			ctx,
+			gomock.Any(),
+			"USD", yearMonth("03-2025"), entity.Rate(82_500_000),
+		).
+		Return(mockRow)
+
+	mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*string)) = "USD"                          // currency
+			*(dest[1].(*entity.YearMonth)) = yearMonth("03-2025") // valid_from
+			*(dest[2].(*entity.Rate)) = 82_500_000                // rate
+			return nil
+		})
+
+	result, err := repo.UpsertExchangeRate(ctx, rate)
+
+	require.NoError(t, err)
+	assert.Equal(t, rate, result)
+}
+
+func TestSubRepository_UpsertExchangeRate_DBError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+
+	mockDB.EXPECT().
+		QueryRow(ctx, gomock.Any(), "USD", yearMonth("03-2025"), entity.Rate(82_500_000)).
+		Return(mockRow)
+
+	mockRow.EXPECT().
+		Scan(gomock.Any()).
+		Return(assert.AnError)
+
+	result, err := repo.UpsertExchangeRate(ctx, &entity.ExchangeRate{Currency: "USD", ValidFrom: yearMonth("03-2025"), Rate: 82_500_000})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to UPSERT exchange rate")
+	assert.Nil(t, result)
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 	entity "subscriptions/internal/entity"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,19 +42,79 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddPriceChange mocks base method.
+func (m *MockRepository) AddPriceChange(ctx context.Context, change *entity.PriceChange) (*entity.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPriceChange", ctx, change)
+	ret0, _ := ret[0].(*entity.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPriceChange indicates an expected call of AddPriceChange.
+func (mr *MockRepositoryMockRecorder) AddPriceChange(ctx, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPriceChange", reflect.TypeOf((*MockRepository)(nil).AddPriceChange), ctx, change)
+}
+
+// CalculateMonthlyBreakdown mocks base method.
+func (m *MockRepository) CalculateMonthlyBreakdown(ctx context.Context, userID string, startDate entity.YearMonth, endDate *entity.YearMonth, currency string) ([]entity.MonthlyCost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalculateMonthlyBreakdown", ctx, userID, startDate, endDate, currency)
+	ret0, _ := ret[0].([]entity.MonthlyCost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalculateMonthlyBreakdown indicates an expected call of CalculateMonthlyBreakdown.
+func (mr *MockRepositoryMockRecorder) CalculateMonthlyBreakdown(ctx, userID, startDate, endDate, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateMonthlyBreakdown", reflect.TypeOf((*MockRepository)(nil).CalculateMonthlyBreakdown), ctx, userID, startDate, endDate, currency)
+}
+
 // CalculateSummary mocks base method.
-func (m *MockRepository) CalculateSummary(ctx context.Context, userID, serviceName, startDate, endDate string) (int, error) {
+func (m *MockRepository) CalculateSummary(ctx context.Context, userID, serviceName string, startDate entity.YearMonth, endDate *entity.YearMonth, currency string) (*entity.Summary, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CalculateSummary", ctx, userID, serviceName, startDate, endDate)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "CalculateSummary", ctx, userID, serviceName, startDate, endDate, currency)
+	ret0, _ := ret[0].(*entity.Summary)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CalculateSummary indicates an expected call of CalculateSummary.
-func (mr *MockRepositoryMockRecorder) CalculateSummary(ctx, userID, serviceName, startDate, endDate any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) CalculateSummary(ctx, userID, serviceName, startDate, endDate, currency any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateSummary", reflect.TypeOf((*MockRepository)(nil).CalculateSummary), ctx, userID, serviceName, startDate, endDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateSummary", reflect.TypeOf((*MockRepository)(nil).CalculateSummary), ctx, userID, serviceName, startDate, endDate, currency)
+}
+
+// CalculateUserSummary mocks base method.
+func (m *MockRepository) CalculateUserSummary(ctx context.Context, userID, serviceName string, startDate entity.YearMonth, endDate *entity.YearMonth, currency string) (*entity.UserSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalculateUserSummary", ctx, userID, serviceName, startDate, endDate, currency)
+	ret0, _ := ret[0].(*entity.UserSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalculateUserSummary indicates an expected call of CalculateUserSummary.
+func (mr *MockRepositoryMockRecorder) CalculateUserSummary(ctx, userID, serviceName, startDate, endDate, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateUserSummary", reflect.TypeOf((*MockRepository)(nil).CalculateUserSummary), ctx, userID, serviceName, startDate, endDate, currency)
+}
+
+// CountList mocks base method.
+func (m *MockRepository) CountList(ctx context.Context, filter entity.SubscriptionFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountList", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountList indicates an expected call of CountList.
+func (mr *MockRepositoryMockRecorder) CountList(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountList", reflect.TypeOf((*MockRepository)(nil).CountList), ctx, filter)
 }
 
 // Create mocks base method.
@@ -72,17 +133,31 @@ func (mr *MockRepositoryMockRecorder) Create(ctx, sub any) *gomock.Call {
 }
 
 // DeleteById mocks base method.
-func (m *MockRepository) DeleteById(ctx context.Context, id string) error {
+func (m *MockRepository) DeleteById(ctx context.Context, id string, version int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteById indicates an expected call of DeleteById.
-func (mr *MockRepositoryMockRecorder) DeleteById(ctx, id any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) DeleteById(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockRepository)(nil).DeleteById), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockRepository)(nil).DeleteById), ctx, id, version)
+}
+
+// DeleteExchangeRate mocks base method.
+func (m *MockRepository) DeleteExchangeRate(ctx context.Context, currency string, validFrom entity.YearMonth) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExchangeRate", ctx, currency, validFrom)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExchangeRate indicates an expected call of DeleteExchangeRate.
+func (mr *MockRepositoryMockRecorder) DeleteExchangeRate(ctx, currency, validFrom any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExchangeRate", reflect.TypeOf((*MockRepository)(nil).DeleteExchangeRate), ctx, currency, validFrom)
 }
 
 // GetById mocks base method.
@@ -100,27 +175,133 @@ func (mr *MockRepositoryMockRecorder) GetById(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockRepository)(nil).GetById), ctx, id)
 }
 
+// GetExchangeRates mocks base method.
+func (m *MockRepository) GetExchangeRates(ctx context.Context, currency string) ([]entity.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRates", ctx, currency)
+	ret0, _ := ret[0].([]entity.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRates indicates an expected call of GetExchangeRates.
+func (mr *MockRepositoryMockRecorder) GetExchangeRates(ctx, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRates", reflect.TypeOf((*MockRepository)(nil).GetExchangeRates), ctx, currency)
+}
+
+// GetHistory mocks base method.
+func (m *MockRepository) GetHistory(ctx context.Context, id string, page entity.HistoryPage) ([]entity.SubscriptionEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, id, page)
+	ret0, _ := ret[0].([]entity.SubscriptionEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockRepositoryMockRecorder) GetHistory(ctx, id, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockRepository)(nil).GetHistory), ctx, id, page)
+}
+
 // GetList mocks base method.
-func (m *MockRepository) GetList(ctx context.Context, offset, limit int, userID, serviceName string) ([]entity.Subscription, error) {
+func (m *MockRepository) GetList(ctx context.Context, filter entity.SubscriptionFilter, sort entity.Sort, page entity.ListPage) ([]entity.Subscription, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetList", ctx, offset, limit, userID, serviceName)
+	ret := m.ctrl.Call(m, "GetList", ctx, filter, sort, page)
 	ret0, _ := ret[0].([]entity.Subscription)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetList indicates an expected call of GetList.
-func (mr *MockRepositoryMockRecorder) GetList(ctx, offset, limit, userID, serviceName any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetList(ctx, filter, sort, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockRepository)(nil).GetList), ctx, filter, sort, page)
+}
+
+// GetPriceChanges mocks base method.
+func (m *MockRepository) GetPriceChanges(ctx context.Context, id string) ([]entity.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceChanges", ctx, id)
+	ret0, _ := ret[0].([]entity.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceChanges indicates an expected call of GetPriceChanges.
+func (mr *MockRepositoryMockRecorder) GetPriceChanges(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceChanges", reflect.TypeOf((*MockRepository)(nil).GetPriceChanges), ctx, id)
+}
+
+// LockById mocks base method.
+func (m *MockRepository) LockById(ctx context.Context, id string) (*entity.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockById", ctx, id)
+	ret0, _ := ret[0].(*entity.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockById indicates an expected call of LockById.
+func (mr *MockRepositoryMockRecorder) LockById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockById", reflect.TypeOf((*MockRepository)(nil).LockById), ctx, id)
+}
+
+// Patch mocks base method.
+func (m *MockRepository) Patch(ctx context.Context, id string, patch *entity.SubscriptionPatch, version int64) (*entity.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, patch, version)
+	ret0, _ := ret[0].(*entity.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockRepositoryMockRecorder) Patch(ctx, id, patch, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockRepository)(nil).Patch), ctx, id, patch, version)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockRepositoryMockRecorder) PurgeDeleted(ctx, before, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockRepository)(nil).GetList), ctx, offset, limit, userID, serviceName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockRepository)(nil).PurgeDeleted), ctx, before, limit)
+}
+
+// Restore mocks base method.
+func (m *MockRepository) Restore(ctx context.Context, id string, version int64) (*entity.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, version)
+	ret0, _ := ret[0].(*entity.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockRepositoryMockRecorder) Restore(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockRepository)(nil).Restore), ctx, id, version)
 }
 
 // Update mocks base method.
-func (m *MockRepository) Update(ctx context.Context, sub *entity.Subscription) error {
+func (m *MockRepository) Update(ctx context.Context, sub *entity.Subscription) (*entity.Subscription, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", ctx, sub)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*entity.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
@@ -128,3 +309,32 @@ func (mr *MockRepositoryMockRecorder) Update(ctx, sub any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, sub)
 }
+
+// UpsertExchangeRate mocks base method.
+func (m *MockRepository) UpsertExchangeRate(ctx context.Context, rate *entity.ExchangeRate) (*entity.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertExchangeRate", ctx, rate)
+	ret0, _ := ret[0].(*entity.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertExchangeRate indicates an expected call of UpsertExchangeRate.
+func (mr *MockRepositoryMockRecorder) UpsertExchangeRate(ctx, rate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertExchangeRate", reflect.TypeOf((*MockRepository)(nil).UpsertExchangeRate), ctx, rate)
+}
+
+// WithTx mocks base method.
+func (m *MockRepository) WithTx(ctx context.Context, opts entity.TxOptions, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", ctx, opts, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockRepositoryMockRecorder) WithTx(ctx, opts, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockRepository)(nil).WithTx), ctx, opts, fn)
+}
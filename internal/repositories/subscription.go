@@ -2,8 +2,6 @@ package repositories
 
 import (
 	"context"
-	"fmt"
-	"strings"
 	"subscriptions/internal/entity"
 	"time"
 )
@@ -12,10 +10,26 @@ import (
 type Repository interface {
 	Create(ctx context.Context, sub *entity.Subscription) (*entity.Subscription, error)
 	GetById(ctx context.Context, id string) (*entity.Subscription, error)
-	Update(ctx context.Context, sub *entity.Subscription) error
-	DeleteById(ctx context.Context, id string) error
-	GetList(ctx context.Context, offset, limit int, userID, serviceName string) ([]entity.Subscription, error)
-	CalculateSummary(ctx context.Context, userID, serviceName, startDate, endDate string) (int, error)
+	LockById(ctx context.Context, id string) (*entity.Subscription, error)
+	Update(ctx context.Context, sub *entity.Subscription) (*entity.Subscription, error)
+	Patch(ctx context.Context, id string, patch *entity.SubscriptionPatch, version int64) (*entity.Subscription, error)
+	DeleteById(ctx context.Context, id string, version int64) error
+	Restore(ctx context.Context, id string, version int64) (*entity.Subscription, error)
+	PurgeDeleted(ctx context.Context, before time.Time, limit int) (int64, error)
+	GetHistory(ctx context.Context, id string, page entity.HistoryPage) ([]entity.SubscriptionEvent, error)
+	AddPriceChange(ctx context.Context, change *entity.PriceChange) (*entity.PriceChange, error)
+	GetPriceChanges(ctx context.Context, id string) ([]entity.PriceChange, error)
+	GetList(ctx context.Context, filter entity.SubscriptionFilter, sort entity.Sort, page entity.ListPage) ([]entity.Subscription, error)
+	CountList(ctx context.Context, filter entity.SubscriptionFilter) (int, error)
+	CalculateSummary(ctx context.Context, userID, serviceName string, startDate entity.YearMonth, endDate *entity.YearMonth, currency string) (*entity.Summary, error)
+	CalculateUserSummary(ctx context.Context, userID, serviceName string, startDate entity.YearMonth, endDate *entity.YearMonth, currency string) (*entity.UserSummary, error)
+	CalculateMonthlyBreakdown(ctx context.Context, userID string, startDate entity.YearMonth, endDate *entity.YearMonth, currency string) ([]entity.MonthlyCost, error)
+
+	UpsertExchangeRate(ctx context.Context, rate *entity.ExchangeRate) (*entity.ExchangeRate, error)
+	GetExchangeRates(ctx context.Context, currency string) ([]entity.ExchangeRate, error)
+	DeleteExchangeRate(ctx context.Context, currency string, validFrom entity.YearMonth) error
+
+	WithTx(ctx context.Context, opts entity.TxOptions, fn func(ctx context.Context) error) error
 }
 
 type subRepository struct {
@@ -25,34 +39,3 @@ type subRepository struct {
 func New(db DB) Repository {
 	return &subRepository{db: db}
 }
-
-func parseDateToDB(dateStr string) (string, error) {
-	if dateStr == "" {
-		return "", nil
-	}
-
-	if len(dateStr) == 7 && strings.Contains(dateStr, "-") {
-		parts := strings.Split(dateStr, "-")
-		if len(parts) != 2 {
-			return "", fmt.Errorf("invalid date format: %s", dateStr)
-		}
-
-		month := parts[0]
-		if month < "01" || month > "12" {
-			return "", fmt.Errorf("invalid month: %s", month)
-		}
-
-		return fmt.Sprintf("%s-%s-01", parts[1], parts[0]), nil
-	}
-
-	_, err := time.Parse("2006-01-02", dateStr)
-	if err != nil {
-		return "", fmt.Errorf("invalid date format: %s", dateStr)
-	}
-
-	return dateStr, nil
-}
-
-func formatTimeToMMYYYY(t time.Time) string {
-	return t.Format("01-2006")
-}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"subscriptions/internal/entity"
+	"time"
+)
+
+// monthIndex переводит дату в порядковый номер месяца, чтобы считать разницу в месяцах
+func monthIndex(t time.Time) int {
+	return t.Year()*12 + int(t.Month()) - 1
+}
+
+// monthStart возвращает первое число месяца по его порядковому номеру
+func monthStart(index int) time.Time {
+	return time.Date(index/12, time.Month(index%12+1), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// billedMonths возвращает количество оплаченных месяцев подписки, попадающих в период [periodStart, periodEnd].
+// Месяцы start_date и end_date подписки считаются оплаченными, бессрочная подписка считается до конца периода
+func billedMonths(startDate time.Time, endDate *time.Time, periodStart, periodEnd time.Time) int {
+	from := max(monthIndex(startDate), monthIndex(periodStart))
+
+	to := monthIndex(periodEnd)
+	if endDate != nil {
+		to = min(to, monthIndex(*endDate))
+	}
+
+	if to < from {
+		return 0
+	}
+
+	return to - from + 1
+}
+
+// monthsPerPeriod — длина периода оплаты в месяцах для помесячных циклов
+var monthsPerPeriod = map[entity.BillingPeriod]int{
+	entity.BillingPeriodMonth:   1,
+	entity.BillingPeriodQuarter: 3,
+	entity.BillingPeriodYear:    12,
+}
+
+// chargesInMonth возвращает количество списаний по подписке в месяце с порядковым номером month.
+// Первое списание происходит в месяц начала подписки, следующие — через каждые billing_interval периодов:
+// годовая подписка списывается раз в год в месяц начала, недельная — каждые 7 дней начиная с 1 числа месяца начала
+func chargesInMonth(ps periodSubscription, month int) int {
+	if billedMonths(ps.startDate, ps.endDate, monthStart(month), monthStart(month)) == 0 {
+		return 0
+	}
+
+	interval := max(ps.sub.BillingInterval, 1)
+	first := monthIndex(ps.startDate)
+
+	if ps.sub.BillingPeriod == entity.BillingPeriodWeek {
+		step := 7 * interval
+		start := monthStart(first)
+
+		// Дни от первого списания до начала месяца и до начала следующего месяца
+		from := int(monthStart(month).Sub(start).Hours() / 24)
+		to := int(monthStart(month+1).Sub(start).Hours() / 24)
+
+		firstCharge := (from + step - 1) / step
+		lastCharge := (to - 1) / step
+
+		return max(lastCharge-firstCharge+1, 0)
+	}
+
+	months, ok := monthsPerPeriod[ps.sub.BillingPeriod]
+	if !ok {
+		months = 1
+	}
+
+	if (month-first)%(months*interval) != 0 {
+		return 0
+	}
+
+	return 1
+}
+
+// billedCost возвращает количество списаний по подписке за период [periodStart, periodEnd]
+// и их стоимость в целевой валюте конвертера. Каждое списание оплачивается по цене, действующей в его месяце
+func billedCost(ps periodSubscription, periodStart, periodEnd time.Time, conv *currencyConverter) (int, entity.Money, error) {
+	from := max(monthIndex(ps.startDate), monthIndex(periodStart))
+
+	charges, cost := 0, entity.Money{Currency: conv.target}
+	for month := from; month <= monthIndex(periodEnd); month++ {
+		monthCharges := chargesInMonth(ps, month)
+		if monthCharges == 0 {
+			continue
+		}
+
+		amount, err := conv.convert(ps.priceIn(month).Times(monthCharges), month)
+		if err != nil {
+			return 0, entity.Money{}, err
+		}
+
+		charges += monthCharges
+		cost.Amount += amount.Amount
+	}
+
+	return charges, cost, nil
+}
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"subscriptions/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBilledMonths(t *testing.T) {
+	date := func(year int, month time.Month) time.Time {
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	}
+	end := func(year int, month time.Month) *time.Time {
+		t := date(year, month)
+		return &t
+	}
+
+	periodStart := date(2025, time.January)
+	periodEnd := date(2025, time.December)
+
+	tests := []struct {
+		name      string
+		startDate time.Time
+		endDate   *time.Time
+		expected  int
+	}{
+		{"inside period", date(2025, time.March), end(2025, time.May), 3},
+		{"open-ended", date(2025, time.July), nil, 6},
+		{"covers whole period", date(2024, time.June), end(2026, time.June), 12},
+		{"overlaps period start", date(2024, time.October), end(2025, time.February), 2},
+		{"single month", date(2025, time.December), end(2025, time.December), 1},
+		{"ends before period", date(2024, time.January), end(2024, time.December), 0},
+		{"starts after period", date(2026, time.January), nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, billedMonths(tt.startDate, tt.endDate, periodStart, periodEnd))
+		})
+	}
+}
+
+func TestChargesInMonth(t *testing.T) {
+	date := func(year int, month time.Month) time.Time {
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	}
+	month := func(year int, m time.Month) int {
+		return monthIndex(date(year, m))
+	}
+	sub := func(period entity.BillingPeriod, interval int, startDate time.Time, endDate *time.Time) periodSubscription {
+		return periodSubscription{
+			sub:       entity.Subscription{BillingPeriod: period, BillingInterval: interval},
+			startDate: startDate,
+			endDate:   endDate,
+		}
+	}
+	endOf2025 := date(2025, time.December)
+
+	tests := []struct {
+		name     string
+		ps       periodSubscription
+		month    int
+		expected int
+	}{
+		{"monthly", sub(entity.BillingPeriodMonth, 1, date(2025, time.March), nil), month(2025, time.July), 1},
+		{"monthly before start", sub(entity.BillingPeriodMonth, 1, date(2025, time.March), nil), month(2025, time.February), 0},
+		{"monthly after end", sub(entity.BillingPeriodMonth, 1, date(2025, time.March), &endOf2025), month(2026, time.January), 0},
+		{"every 2 months on", sub(entity.BillingPeriodMonth, 2, date(2025, time.January), nil), month(2025, time.May), 1},
+		{"every 2 months off", sub(entity.BillingPeriodMonth, 2, date(2025, time.January), nil), month(2025, time.June), 0},
+		{"quarterly on", sub(entity.BillingPeriodQuarter, 1, date(2025, time.February), nil), month(2025, time.August), 1},
+		{"quarterly off", sub(entity.BillingPeriodQuarter, 1, date(2025, time.February), nil), month(2025, time.September), 0},
+		{"annual anniversary", sub(entity.BillingPeriodYear, 1, date(2024, time.October), nil), month(2025, time.October), 1},
+		{"annual off", sub(entity.BillingPeriodYear, 1, date(2024, time.October), nil), month(2025, time.November), 0},
+		// Списания 1, 8, 15, 22 и 29 января
+		{"weekly january", sub(entity.BillingPeriodWeek, 1, date(2025, time.January), nil), month(2025, time.January), 5},
+		// Списания 5, 12, 19 и 26 февраля
+		{"weekly february", sub(entity.BillingPeriodWeek, 1, date(2025, time.January), nil), month(2025, time.February), 4},
+		// Списания 1, 15 и 29 января
+		{"biweekly january", sub(entity.BillingPeriodWeek, 2, date(2025, time.January), nil), month(2025, time.January), 3},
+		// Списания 12 и 26 февраля
+		{"biweekly february", sub(entity.BillingPeriodWeek, 2, date(2025, time.January), nil), month(2025, time.February), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, chargesInMonth(tt.ps, tt.month))
+		})
+	}
+}
+
+func TestBilledCost(t *testing.T) {
+	date := func(year int, month time.Month) time.Time {
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	conv := &currencyConverter{target: entity.BaseCurrency}
+
+	// Годовая подписка с 10-2023: списания в 10-2023, 10-2024, 10-2025
+	annual := periodSubscription{
+		sub: entity.Subscription{
+			Price:           entity.Money{Amount: 3990, Currency: entity.BaseCurrency},
+			BillingPeriod:   entity.BillingPeriodYear,
+			BillingInterval: 1,
+		},
+		startDate: date(2023, time.October),
+	}
+
+	tests := []struct {
+		name            string
+		ps              periodSubscription
+		periodStart     time.Time
+		periodEnd       time.Time
+		expectedCharges int
+		expectedCost    entity.Amount
+	}{
+		{"annual in year", annual, date(2025, time.January), date(2025, time.December), 1, 3990},
+		{"annual in two years", annual, date(2024, time.January), date(2025, time.December), 2, 7980},
+		{"annual before anniversary", annual, date(2025, time.January), date(2025, time.September), 0, 0},
+		{
+			// Недельная подписка с 01-2025: 53 списания за 2025 год, каждые 7 дней с 1 января по 31 декабря
+			"weekly in year",
+			periodSubscription{
+				sub: entity.Subscription{
+					Price:           entity.Money{Amount: 100, Currency: entity.BaseCurrency},
+					BillingPeriod:   entity.BillingPeriodWeek,
+					BillingInterval: 1,
+				},
+				startDate: date(2025, time.January),
+			},
+			date(2025, time.January), date(2025, time.December), 53, 5300,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			charges, cost, err := billedCost(tt.ps, tt.periodStart, tt.periodEnd, conv)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCharges, charges)
+			assert.Equal(t, tt.expectedCost, cost.Amount)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"subscriptions/internal/entity"
+)
+
+func (r *subRepository) CalculateMonthlyBreakdown(ctx context.Context, userID string,
+	startDate entity.YearMonth, endDate *entity.YearMonth, currency string) ([]entity.MonthlyCost, error) {
+	periodStart, periodEnd, err := parsePeriod(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	subs, err := r.getInPeriod(ctx, userID, "", periodStart, periodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate monthly breakdown: %w", err)
+	}
+
+	conv, err := r.newCurrencyConverter(ctx, currency, subs, periodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate monthly breakdown: %w", err)
+	}
+
+	// Возвращаем все месяцы периода, включая месяцы без трат, чтобы временной ряд был непрерывным
+	breakdown := make([]entity.MonthlyCost, 0, monthIndex(periodEnd)-monthIndex(periodStart)+1)
+
+	for index := monthIndex(periodStart); index <= monthIndex(periodEnd); index++ {
+		monthly := entity.MonthlyCost{
+			Month:         entity.YearMonthOf(monthStart(index)),
+			TotalCost:     entity.Money{Currency: conv.target},
+			Subscriptions: []entity.MonthlyCostItem{},
+		}
+
+		// В месяц попадают только подписки, по которым в нем было списание
+		for _, ps := range subs {
+			charges := chargesInMonth(ps, index)
+			if charges == 0 {
+				continue
+			}
+
+			cost, err := conv.convert(ps.priceIn(index).Times(charges), index)
+			if err != nil {
+				return nil, fmt.Errorf("failed to calculate monthly breakdown: %w", err)
+			}
+
+			item := entity.MonthlyCostItem{
+				Subscription: ps.sub,
+				Charges:      charges,
+				Cost:         cost,
+			}
+
+			monthly.Subscriptions = append(monthly.Subscriptions, item)
+			monthly.TotalCost.Amount += item.Cost.Amount
+		}
+
+		breakdown = append(breakdown, monthly)
+	}
+
+	return breakdown, nil
+}
@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSubRepository_CalculateMonthlyBreakdown_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	userID := "user-123"
+	startDate := yearMonth("01-2025")
+	endDate := yearMonthPtr("04-2025")
+
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Any(),
+			userID, yearMonth("01-2025"), yearMonth("04-2025"),
+		).
+		Return(mockRows, nil)
+
+	expectPeriodRows(mockRows, []periodRow{
+		{
+			id:        "sub-1",
+			name:      "Yandex Plus",
+			price:     400,
+			userId:    userID,
+			startDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			endDate:   sql.NullTime{Time: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+		{
+			id:        "sub-2",
+			name:      "Kinopoisk",
+			price:     300,
+			userId:    userID,
+			startDate: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+	})
+
+	breakdown, err := repo.CalculateMonthlyBreakdown(ctx, userID, startDate, endDate, "")
+
+	require.NoError(t, err)
+	require.Len(t, breakdown, 4)
+
+	assert.Equal(t, "01-2025", breakdown[0].Month.String())
+	assert.Equal(t, entity.Amount(400), breakdown[0].TotalCost.Amount)
+	require.Len(t, breakdown[0].Subscriptions, 1)
+	assert.Equal(t, "sub-1", breakdown[0].Subscriptions[0].Subscription.Id)
+
+	assert.Equal(t, "02-2025", breakdown[1].Month.String())
+	assert.Equal(t, entity.Amount(700), breakdown[1].TotalCost.Amount)
+	assert.Len(t, breakdown[1].Subscriptions, 2)
+
+	assert.Equal(t, "03-2025", breakdown[2].Month.String())
+	assert.Equal(t, entity.Amount(300), breakdown[2].TotalCost.Amount)
+	require.Len(t, breakdown[2].Subscriptions, 1)
+	assert.Equal(t, "sub-2", breakdown[2].Subscriptions[0].Subscription.Id)
+
+	assert.Equal(t, "04-2025", breakdown[3].Month.String())
+	assert.Equal(t, entity.Amount(300), breakdown[3].TotalCost.Amount)
+}
+
+func TestSubRepository_CalculateMonthlyBreakdown_EmptyMonths(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	userID := "user-123"
+
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Any(),
+			userID, yearMonth("11-2024"), yearMonth("02-2025"),
+		).
+		Return(mockRows, nil)
+
+	expectPeriodRows(mockRows, nil)
+
+	breakdown, err := repo.CalculateMonthlyBreakdown(ctx, userID, yearMonth("11-2024"), yearMonthPtr("02-2025"), "")
+
+	require.NoError(t, err)
+	require.Len(t, breakdown, 4)
+
+	months := []string{"11-2024", "12-2024", "01-2025", "02-2025"}
+	for i, monthly := range breakdown {
+		assert.Equal(t, months[i], monthly.Month.String())
+		assert.Equal(t, entity.Amount(0), monthly.TotalCost.Amount)
+		assert.Empty(t, monthly.Subscriptions)
+	}
+}
+
+func TestSubRepository_CalculateMonthlyBreakdown_InvalidPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	breakdown, err := repo.CalculateMonthlyBreakdown(context.Background(), "user-123", yearMonth("05-2025"), yearMonthPtr("01-2025"), "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid period")
+	assert.Nil(t, breakdown)
+}
+
+func TestSubRepository_CalculateMonthlyBreakdown_DBError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	userID := "user-123"
+
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Any(),
+			userID, yearMonth("01-2025"), yearMonth("12-2025"),
+		).
+		Return(nil, assert.AnError)
+
+	breakdown, err := repo.CalculateMonthlyBreakdown(ctx, userID, yearMonth("01-2025"), yearMonthPtr("12-2025"), "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to calculate monthly breakdown")
+	assert.Nil(t, breakdown)
+}
+
+func TestSubRepository_CalculateMonthlyBreakdown_PriceChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	userID := "user-123"
+
+	mockDB.EXPECT().
+		Query(ctx, gomock.Any(), userID, yearMonth("01-2025"), yearMonth("03-2025")).
+		Return(mockRows, nil)
+
+	expectPeriodRows(mockRows, []periodRow{
+		{
+			id:           "sub-1",
+			name:         "Yandex Plus",
+			price:        300,
+			userId:       userID,
+			startDate:    time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			changeMonths: []entity.YearMonth{yearMonth("02-2025")},
+			changePrices: []entity.Amount{400},
+		},
+	})
+
+	breakdown, err := repo.CalculateMonthlyBreakdown(ctx, userID, yearMonth("01-2025"), yearMonthPtr("03-2025"), "")
+
+	require.NoError(t, err)
+	require.Len(t, breakdown, 3)
+	assert.Equal(t, entity.Amount(300), breakdown[0].TotalCost.Amount)
+	assert.Equal(t, entity.Amount(400), breakdown[1].TotalCost.Amount)
+	assert.Equal(t, entity.Amount(400), breakdown[2].TotalCost.Amount)
+}
@@ -3,41 +3,50 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"subscriptions/internal/entity"
 )
 
-func (r *subRepository) CalculateSummary(ctx context.Context, userID, serviceName, startDate, endDate string) (int, error) {
-	var totalCost int
+func (r *subRepository) CalculateSummary(ctx context.Context, userID, serviceName string,
+	startDate entity.YearMonth, endDate *entity.YearMonth, currency string) (*entity.Summary, error) {
+	periodStart, periodEnd, err := parsePeriod(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	subs, err := r.getInPeriod(ctx, userID, serviceName, periodStart, periodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate summary: %w", err)
+	}
 
-	startDateForDB, err := parseDateToDB(startDate)
+	conv, err := r.newCurrencyConverter(ctx, currency, subs, periodEnd)
 	if err != nil {
-		return 0, fmt.Errorf("invalid start date: %v", err)
+		return nil, fmt.Errorf("failed to calculate summary: %w", err)
 	}
 
-	query := `
-		SELECT COALESCE(SUM(price), 0) 
-		FROM subscriptions 
-		WHERE user_id = $1 AND service_name = $2 
-		AND start_date >= $3
-	`
+	summary := &entity.Summary{TotalCost: entity.Money{Currency: conv.target}}
 
-	args := []interface{}{userID, serviceName, startDateForDB}
+	for _, ps := range subs {
+		months := billedMonths(ps.startDate, ps.endDate, periodStart, periodEnd)
+		if months == 0 {
+			continue
+		}
 
-	if endDate != "" {
-		query += " AND (end_date <= $4 OR end_date IS NULL)"
-		endDateForDB, err := parseDateToDB(endDate)
+		charges, cost, err := billedCost(ps, periodStart, periodEnd, conv)
 		if err != nil {
-			return 0, fmt.Errorf("invalid start date: %v", err)
+			return nil, fmt.Errorf("failed to calculate summary: %w", err)
 		}
-		args = append(args, endDateForDB)
-	} else {
-		query += " AND (end_date IS NULL OR end_date >= $4)"
-		args = append(args, startDateForDB)
-	}
 
-	err = r.db.QueryRow(ctx, query, args...).Scan(&totalCost)
-	if err != nil {
-		return 0, fmt.Errorf("failed to calculate summary: %w", err)
+		item := entity.SummaryItem{
+			Subscription: ps.sub,
+			Months:       months,
+			Charges:      charges,
+			Cost:         cost,
+		}
+
+		summary.Subscriptions = append(summary.Subscriptions, item)
+		summary.Months += item.Months
+		summary.TotalCost.Amount += item.Cost.Amount
 	}
 
-	return totalCost, nil
+	return summary, nil
 }
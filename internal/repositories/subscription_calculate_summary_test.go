@@ -2,50 +2,158 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"subscriptions/internal/entity"
 	"subscriptions/internal/repositories/mocks"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
+type periodRow struct {
+	id              string
+	name            string
+	price           entity.Amount
+	userId          string
+	startDate       time.Time
+	endDate         sql.NullTime
+	billingPeriod   entity.BillingPeriod
+	billingInterval int
+	currency        string
+	// Изменения цены: месяц вступления в силу и новая цена
+	changeMonths []entity.YearMonth
+	changePrices []entity.Amount
+}
+
+// yearMonth разбирает дату MM-YYYY для тестовых данных
+func yearMonth(s string) entity.YearMonth {
+	ym, err := entity.ParseYearMonth(s)
+	if err != nil {
+		panic(err)
+	}
+	return ym
+}
+
+func yearMonthPtr(s string) *entity.YearMonth {
+	ym := yearMonth(s)
+	return &ym
+}
+
+// expectPeriodRows эмулирует построчное чтение результата запроса
+func expectPeriodRows(mockRows *mocks.MockRows, rows []periodRow) {
+	calls := make([]any, 0, len(rows)*2+1)
+
+	for _, row := range rows {
+		// По умолчанию подписка оплачивается ежемесячно
+		if row.billingPeriod == "" {
+			row.billingPeriod = entity.BillingPeriodMonth
+		}
+		if row.billingInterval == 0 {
+			row.billingInterval = 1
+		}
+		if row.currency == "" {
+			row.currency = entity.BaseCurrency
+		}
+
+		calls = append(calls,
+			mockRows.EXPECT().Next().Return(true),
+			mockRows.EXPECT().
+				Scan(gomock.Any()).
+				DoAndReturn(func(dest ...interface{}) error {
+					*(dest[0].(*string)) = row.id
+					*(dest[1].(*string)) = row.name
+					*(dest[2].(*entity.Amount)) = row.price
+					*(dest[3].(*string)) = row.userId
+					*(dest[4].(*entity.YearMonth)) = entity.YearMonthOf(row.startDate)
+					*(dest[5].(**entity.YearMonth)) = nil
+					if row.endDate.Valid {
+						endDate := entity.YearMonthOf(row.endDate.Time)
+						*(dest[5].(**entity.YearMonth)) = &endDate
+					}
+					*(dest[6].(*entity.BillingPeriod)) = row.billingPeriod
+					*(dest[7].(*int)) = row.billingInterval
+					*(dest[8].(*string)) = row.currency
+					*(dest[9].(*[]entity.YearMonth)) = row.changeMonths
+					*(dest[10].(*[]entity.Amount)) = row.changePrices
+					return nil
+				}),
+		)
+	}
+
+	calls = append(calls, mockRows.EXPECT().Next().Return(false))
+	gomock.InOrder(calls...)
+
+	mockRows.EXPECT().Err().Return(nil).AnyTimes()
+	mockRows.EXPECT().Close().AnyTimes()
+}
+
 func TestSubRepository_CalculateSummary_SuccessWithEndDate(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockDB := mocks.NewMockDB(ctrl)
-	mockRow := mocks.NewMockRow(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
 	repo := &subRepository{db: mockDB}
 
 	ctx := context.Background()
 	userID := "user-123"
 	serviceName := "Yandex Plus"
-	startDate := "01-2025"
-	endDate := "12-2025"
+	startDate := yearMonth("01-2025")
+	endDate := yearMonthPtr("12-2025")
 
-	expectedStartDateStr := "2025-01-01"
-	expectedEndDateStr := "2025-12-01"
+	expectedStartDateStr := yearMonth("01-2025")
+	expectedEndDateStr := yearMonth("12-2025")
 
 	mockDB.EXPECT().
-		QueryRow(
+		Query(
 			ctx,
 			gomock.Any(),
 			userID, serviceName, expectedStartDateStr, expectedEndDateStr,
 		).
-		Return(mockRow)
-
-	mockRow.EXPECT().
-		Scan(gomock.Any()).
-		DoAndReturn(func(dest ...interface{}) error {
-			*(dest[0].(*int)) = 4500 
-			return nil
-		})
-
-	totalCost, err := repo.CalculateSummary(ctx, userID, serviceName, startDate, endDate)
+		Return(mockRows, nil)
+
+	expectPeriodRows(mockRows, []periodRow{
+		{
+			// Бессрочная подписка на весь период: 12 месяцев по 400
+			id:        "sub-1",
+			name:      serviceName,
+			price:     400,
+			userId:    userID,
+			startDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			// Подписка частично до начала периода: в период попадают 01-2025..03-2025
+			id:        "sub-2",
+			name:      serviceName,
+			price:     300,
+			userId:    userID,
+			startDate: time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC),
+			endDate:   sql.NullTime{Time: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+	})
+
+	summary, err := repo.CalculateSummary(ctx, userID, serviceName, startDate, endDate, "")
 
 	require.NoError(t, err)
-	assert.Equal(t, 4500, totalCost)
+	assert.Equal(t, "RUB", summary.TotalCost.Currency)
+	assert.Equal(t, entity.Amount(5700), summary.TotalCost.Amount)
+	assert.Equal(t, 15, summary.Months)
+	require.Len(t, summary.Subscriptions, 2)
+
+	assert.Equal(t, "sub-1", summary.Subscriptions[0].Subscription.Id)
+	assert.Equal(t, "01-2025", summary.Subscriptions[0].Subscription.StartDate.String())
+	assert.Nil(t, summary.Subscriptions[0].Subscription.EndDate)
+	assert.Equal(t, 12, summary.Subscriptions[0].Months)
+	assert.Equal(t, entity.Amount(4800), summary.Subscriptions[0].Cost.Amount)
+
+	assert.Equal(t, "sub-2", summary.Subscriptions[1].Subscription.Id)
+	assert.Equal(t, "11-2024", summary.Subscriptions[1].Subscription.StartDate.String())
+	assert.Equal(t, "03-2025", summary.Subscriptions[1].Subscription.EndDate.String())
+	assert.Equal(t, 3, summary.Subscriptions[1].Months)
+	assert.Equal(t, entity.Amount(900), summary.Subscriptions[1].Cost.Amount)
 }
 
 func TestSubRepository_CalculateSummary_SuccessWithoutEndDate(t *testing.T) {
@@ -53,36 +161,87 @@ func TestSubRepository_CalculateSummary_SuccessWithoutEndDate(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockDB := mocks.NewMockDB(ctrl)
-	mockRow := mocks.NewMockRow(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
 	repo := &subRepository{db: mockDB}
 
 	ctx := context.Background()
 	userID := "user-123"
 	serviceName := "Yandex Plus"
-	startDate := "01-2025"
-	endDate := "" 
+	startDate := yearMonth("01-2025")
+	var endDate *entity.YearMonth
 
-	expectedStartDateStr := "2025-01-01"
+	expectedStartDateStr := yearMonth("01-2025")
+	// Без end_date период считается до текущего месяца
+	expectedEndDateStr := entity.CurrentYearMonth()
 
 	mockDB.EXPECT().
-		QueryRow(
+		Query(
 			ctx,
 			gomock.Any(),
-			userID, serviceName, expectedStartDateStr, expectedStartDateStr,
+			userID, serviceName, expectedStartDateStr, expectedEndDateStr,
 		).
-		Return(mockRow)
+		Return(mockRows, nil)
+
+	expectPeriodRows(mockRows, []periodRow{
+		{
+			id:        "sub-1",
+			name:      serviceName,
+			price:     500,
+			userId:    userID,
+			startDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			endDate:   sql.NullTime{Time: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+	})
+
+	summary, err := repo.CalculateSummary(ctx, userID, serviceName, startDate, endDate, "")
 
-	mockRow.EXPECT().
-		Scan(gomock.Any()).
-		DoAndReturn(func(dest ...interface{}) error {
-			*(dest[0].(*int)) = 3000 
-			return nil
-		})
+	require.NoError(t, err)
+	assert.Equal(t, entity.Amount(3000), summary.TotalCost.Amount)
+	assert.Equal(t, 6, summary.Months)
+}
 
-	totalCost, err := repo.CalculateSummary(ctx, userID, serviceName, startDate, endDate)
+func TestSubRepository_CalculateSummary_AnnualBilling(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	userID := "user-123"
+	serviceName := "Yandex Plus"
+
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Any(),
+			userID, serviceName, yearMonth("01-2025"), yearMonth("12-2025"),
+		).
+		Return(mockRows, nil)
+
+	expectPeriodRows(mockRows, []periodRow{
+		{
+			// Годовой тариф списывается один раз в месяц начала подписки
+			id:              "sub-1",
+			name:            serviceName,
+			price:           3990,
+			userId:          userID,
+			startDate:       time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+			billingPeriod:   entity.BillingPeriodYear,
+			billingInterval: 1,
+		},
+	})
+
+	summary, err := repo.CalculateSummary(ctx, userID, serviceName, yearMonth("01-2025"), yearMonthPtr("12-2025"), "")
 
 	require.NoError(t, err)
-	assert.Equal(t, 3000, totalCost)
+	assert.Equal(t, entity.Amount(3990), summary.TotalCost.Amount)
+	assert.Equal(t, 12, summary.Months)
+	require.Len(t, summary.Subscriptions, 1)
+	assert.Equal(t, 12, summary.Subscriptions[0].Months)
+	assert.Equal(t, 1, summary.Subscriptions[0].Charges)
+	assert.Equal(t, entity.Amount(3990), summary.Subscriptions[0].Cost.Amount)
 }
 
 func TestSubRepository_CalculateSummary_ZeroResult(t *testing.T) {
@@ -90,38 +249,34 @@ func TestSubRepository_CalculateSummary_ZeroResult(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockDB := mocks.NewMockDB(ctrl)
-	mockRow := mocks.NewMockRow(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
 	repo := &subRepository{db: mockDB}
 
 	ctx := context.Background()
 	userID := "user-123"
 	serviceName := "Yandex Plus"
-	startDate := "01-2025"
-	endDate := "12-2025"
+	startDate := yearMonth("01-2025")
+	endDate := yearMonthPtr("12-2025")
 
-	expectedStartDateStr := "2025-01-01"
-	expectedEndDateStr := "2025-12-01"
+	expectedStartDateStr := yearMonth("01-2025")
+	expectedEndDateStr := yearMonth("12-2025")
 
 	mockDB.EXPECT().
-		QueryRow(
+		Query(
 			ctx,
 			gomock.Any(),
 			userID, serviceName, expectedStartDateStr, expectedEndDateStr,
 		).
-		Return(mockRow)
+		Return(mockRows, nil)
 
-	// COALESCE вернет 0 если нет подписок
-	mockRow.EXPECT().
-		Scan(gomock.Any()).
-		DoAndReturn(func(dest ...interface{}) error {
-			*(dest[0].(*int)) = 0
-			return nil
-		})
+	expectPeriodRows(mockRows, nil)
 
-	totalCost, err := repo.CalculateSummary(ctx, userID, serviceName, startDate, endDate)
+	summary, err := repo.CalculateSummary(ctx, userID, serviceName, startDate, endDate, "")
 
 	require.NoError(t, err)
-	assert.Equal(t, 0, totalCost)
+	assert.Equal(t, entity.Amount(0), summary.TotalCost.Amount)
+	assert.Equal(t, 0, summary.Months)
+	assert.Empty(t, summary.Subscriptions)
 }
 
 func TestSubRepository_CalculateSummary_InvalidStartDate(t *testing.T) {
@@ -134,18 +289,17 @@ func TestSubRepository_CalculateSummary_InvalidStartDate(t *testing.T) {
 	ctx := context.Background()
 	userID := "user-123"
 	serviceName := "Yandex Plus"
-	startDate := "invalid-date" // Невалидная дата
-	endDate := "12-2025"
+	startDate := entity.YearMonth{} // Дата начала не указана
+	endDate := yearMonthPtr("12-2025")
 
-	totalCost, err := repo.CalculateSummary(ctx, userID, serviceName, startDate, endDate)
+	summary, err := repo.CalculateSummary(ctx, userID, serviceName, startDate, endDate, "")
 
-	
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid start date")
-	assert.Equal(t, 0, totalCost)
+	assert.Nil(t, summary)
 }
 
-func TestSubRepository_CalculateSummary_InvalidEndDate(t *testing.T) {
+func TestSubRepository_CalculateSummary_EndBeforeStart(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -153,16 +307,12 @@ func TestSubRepository_CalculateSummary_InvalidEndDate(t *testing.T) {
 	repo := &subRepository{db: mockDB}
 
 	ctx := context.Background()
-	userID := "user-123"
-	serviceName := "Yandex Plus"
-	startDate := "01-2025"
-	endDate := "invalid-date" 
 
-	totalCost, err := repo.CalculateSummary(ctx, userID, serviceName, startDate, endDate)
+	summary, err := repo.CalculateSummary(ctx, "user-123", "Yandex Plus", yearMonth("12-2025"), yearMonthPtr("01-2025"), "")
 
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "invalid start date")
-	assert.Equal(t, 0, totalCost)
+	assert.Contains(t, err.Error(), "invalid period")
+	assert.Nil(t, summary)
 }
 
 func TestSubRepository_CalculateSummary_DBError(t *testing.T) {
@@ -170,34 +320,75 @@ func TestSubRepository_CalculateSummary_DBError(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockDB := mocks.NewMockDB(ctrl)
-	mockRow := mocks.NewMockRow(ctrl)
 	repo := &subRepository{db: mockDB}
 
 	ctx := context.Background()
 	userID := "user-123"
 	serviceName := "Yandex Plus"
-	startDate := "01-2025"
-	endDate := "12-2025"
+	startDate := yearMonth("01-2025")
+	endDate := yearMonthPtr("12-2025")
 
-	expectedStartDateStr := "2025-01-01"
-	expectedEndDateStr := "2025-12-01"
+	expectedStartDateStr := yearMonth("01-2025")
+	expectedEndDateStr := yearMonth("12-2025")
 
+	// Эмулируем ошибку БД
 	mockDB.EXPECT().
-		QueryRow(
+		Query(
 			ctx,
 			gomock.Any(),
 			userID, serviceName, expectedStartDateStr, expectedEndDateStr,
 		).
-		Return(mockRow)
+		Return(nil, assert.AnError)
 
-	// Эмулируем ошибку БД
-	mockRow.EXPECT().
-		Scan(gomock.Any()).
-		Return(assert.AnError)
-
-	totalCost, err := repo.CalculateSummary(ctx, userID, serviceName, startDate, endDate)
+	summary, err := repo.CalculateSummary(ctx, userID, serviceName, startDate, endDate, "")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to calculate summary")
-	assert.Equal(t, 0, totalCost)
+	assert.Nil(t, summary)
+}
+
+func TestSubRepository_CalculateSummary_PriceChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	userID := "user-123"
+	serviceName := "Yandex Plus"
+
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Cond(func(sql any) bool {
+				return assert.Contains(t, sql, "FROM subscription_price_changes WHERE subscription_id = s.id ORDER BY effective_from")
+			}),
+			userID, serviceName, yearMonth("01-2025"), yearMonth("12-2025"),
+		).
+		Return(mockRows, nil)
+
+	expectPeriodRows(mockRows, []periodRow{
+		{
+			// 299 до апреля, 399 с апреля; изменение с будущего месяца периода не касается
+			id:           "sub-1",
+			name:         serviceName,
+			price:        29900,
+			userId:       userID,
+			startDate:    time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+			endDate:      sql.NullTime{Time: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+			changeMonths: []entity.YearMonth{yearMonth("04-2025"), yearMonth("01-2026")},
+			changePrices: []entity.Amount{39900, 49900},
+		},
+	})
+
+	summary, err := repo.CalculateSummary(ctx, userID, serviceName, yearMonth("01-2025"), yearMonthPtr("12-2025"), "")
+
+	require.NoError(t, err)
+	// 01..03 по 299 и 04..06 по 399
+	assert.Equal(t, entity.Amount(3*29900+3*39900), summary.TotalCost.Amount)
+	assert.Equal(t, 6, summary.Months)
+	require.Len(t, summary.Subscriptions, 1)
+	assert.Equal(t, entity.Amount(29900), summary.Subscriptions[0].Subscription.Price.Amount)
 }
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"subscriptions/internal/entity"
+)
+
+func (r *subRepository) CalculateUserSummary(ctx context.Context, userID, serviceName string,
+	startDate entity.YearMonth, endDate *entity.YearMonth, currency string) (*entity.UserSummary, error) {
+	periodStart, periodEnd, err := parsePeriod(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	subs, err := r.getInPeriod(ctx, userID, serviceName, periodStart, periodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate user summary: %w", err)
+	}
+
+	conv, err := r.newCurrencyConverter(ctx, currency, subs, periodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate user summary: %w", err)
+	}
+
+	summary := &entity.UserSummary{TotalCost: entity.Money{Currency: conv.target}}
+	services := make(map[string]*entity.ServiceSummary)
+
+	for _, ps := range subs {
+		months := billedMonths(ps.startDate, ps.endDate, periodStart, periodEnd)
+		if months == 0 {
+			continue
+		}
+
+		charges, cost, err := billedCost(ps, periodStart, periodEnd, conv)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate user summary: %w", err)
+		}
+
+		item := entity.SummaryItem{
+			Subscription: ps.sub,
+			Months:       months,
+			Charges:      charges,
+			Cost:         cost,
+		}
+
+		service, ok := services[ps.sub.Name]
+		if !ok {
+			service = &entity.ServiceSummary{
+				ServiceName: ps.sub.Name,
+				TotalCost:   entity.Money{Currency: conv.target},
+			}
+			services[ps.sub.Name] = service
+		}
+
+		service.Subscriptions = append(service.Subscriptions, item)
+		service.Months += item.Months
+		service.TotalCost.Amount += item.Cost.Amount
+
+		summary.Months += item.Months
+		summary.TotalCost.Amount += item.Cost.Amount
+	}
+
+	summary.Services = make([]entity.ServiceSummary, 0, len(services))
+	for _, service := range services {
+		summary.Services = append(summary.Services, *service)
+	}
+
+	sort.Slice(summary.Services, func(i, j int) bool {
+		return summary.Services[i].ServiceName < summary.Services[j].ServiceName
+	})
+
+	return summary, nil
+}
@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSubRepository_CalculateUserSummary_GroupsByService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	userID := "user-123"
+
+	// Без service_name фильтр по сервису в запрос не передается
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Any(),
+			userID, yearMonth("01-2025"), yearMonth("06-2025"),
+		).
+		Return(mockRows, nil)
+
+	expectPeriodRows(mockRows, []periodRow{
+		{
+			id:        "sub-1",
+			name:      "Yandex Plus",
+			price:     400,
+			userId:    userID,
+			startDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			endDate:   sql.NullTime{Time: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+		{
+			id:        "sub-2",
+			name:      "Kinopoisk",
+			price:     300,
+			userId:    userID,
+			startDate: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			id:        "sub-3",
+			name:      "Yandex Plus",
+			price:     500,
+			userId:    userID,
+			startDate: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+	})
+
+	summary, err := repo.CalculateUserSummary(ctx, userID, "", yearMonth("01-2025"), yearMonthPtr("06-2025"), "")
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.Amount(3700), summary.TotalCost.Amount)
+	assert.Equal(t, 10, summary.Months)
+	require.Len(t, summary.Services, 2)
+
+	assert.Equal(t, "Kinopoisk", summary.Services[0].ServiceName)
+	assert.Equal(t, entity.Amount(1500), summary.Services[0].TotalCost.Amount)
+	assert.Equal(t, 5, summary.Services[0].Months)
+	assert.Len(t, summary.Services[0].Subscriptions, 1)
+
+	assert.Equal(t, "Yandex Plus", summary.Services[1].ServiceName)
+	assert.Equal(t, entity.Amount(2200), summary.Services[1].TotalCost.Amount)
+	assert.Equal(t, 5, summary.Services[1].Months)
+	assert.Len(t, summary.Services[1].Subscriptions, 2)
+}
+
+func TestSubRepository_CalculateUserSummary_WithServiceFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	userID := "user-123"
+	serviceName := "Kinopoisk"
+
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Any(),
+			userID, serviceName, yearMonth("01-2025"), yearMonth("12-2025"),
+		).
+		Return(mockRows, nil)
+
+	expectPeriodRows(mockRows, []periodRow{
+		{
+			id:        "sub-2",
+			name:      serviceName,
+			price:     300,
+			userId:    userID,
+			startDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	})
+
+	summary, err := repo.CalculateUserSummary(ctx, userID, serviceName, yearMonth("01-2025"), yearMonthPtr("12-2025"), "")
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.Amount(3600), summary.TotalCost.Amount)
+	require.Len(t, summary.Services, 1)
+	assert.Equal(t, serviceName, summary.Services[0].ServiceName)
+}
+
+func TestSubRepository_CalculateUserSummary_Empty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	userID := "user-123"
+
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Any(),
+			userID, yearMonth("01-2025"), yearMonth("12-2025"),
+		).
+		Return(mockRows, nil)
+
+	expectPeriodRows(mockRows, nil)
+
+	summary, err := repo.CalculateUserSummary(ctx, userID, "", yearMonth("01-2025"), yearMonthPtr("12-2025"), "")
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.Amount(0), summary.TotalCost.Amount)
+	assert.Empty(t, summary.Services)
+}
+
+func TestSubRepository_CalculateUserSummary_DBError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	userID := "user-123"
+
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Any(),
+			userID, yearMonth("01-2025"), yearMonth("12-2025"),
+		).
+		Return(nil, assert.AnError)
+
+	summary, err := repo.CalculateUserSummary(ctx, userID, "", yearMonth("01-2025"), yearMonthPtr("12-2025"), "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to calculate user summary")
+	assert.Nil(t, summary)
+}
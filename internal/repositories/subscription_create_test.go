@@ -3,12 +3,12 @@ package repositories
 
 import (
 	"context"
-	"database/sql"
 	"testing"
 	"time"
 
 	"subscriptions/internal/entity"
 	"subscriptions/internal/repositories/mocks"
+	"subscriptions/pkg/auth"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,39 +27,38 @@ func TestSubRepository_Create_Success(t *testing.T) {
 	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
 
 	sub := &entity.Subscription{
-		Name:      "Yandex Plus",
-		Price:     1500,
-		UserId:    userId,
-		StartDate: "01-2025",
-		EndDate:   "12-2025",
+		Name:            "Yandex Plus",
+		Price:           entity.Money{Amount: 1500, Currency: "RUB"},
+		UserId:          userId,
+		StartDate:       yearMonth("01-2025"),
+		EndDate:         yearMonthPtr("12-2025"),
+		BillingPeriod:   entity.BillingPeriodMonth,
+		BillingInterval: 1,
 	}
 
-	expectedStartDateStr := "2025-01-01"
-	expectedEndDateStr := "2025-12-01"
-
 	mockDB.EXPECT().
 		QueryRow(
 			ctx,
 			gomock.Any(), // SQL
-			"Yandex Plus", 1500, userId, expectedStartDateStr, expectedEndDateStr,
+			"Yandex Plus", entity.Amount(1500), userId, sub.StartDate, sub.EndDate, entity.BillingPeriodMonth, 1, "RUB", gomock.Nil(),
 		).
 		Return(mockRow)
 
-	expectedStartDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
-	expectedEndDate := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	expectedStartDate := yearMonth("01-2025")
+	expectedEndDate := yearMonth("12-2025")
 
 	mockRow.EXPECT().
 		Scan(gomock.Any()).
 		DoAndReturn(func(dest ...interface{}) error {
-			*(dest[0].(*string)) = "sub-123"            // id
-			*(dest[1].(*string)) = "Yandex Plus"        // service_name
-			*(dest[2].(*int)) = 1500                    // price
-			*(dest[3].(*string)) = userId               // user_id
-			*(dest[4].(*time.Time)) = expectedStartDate // start_date
-			*(dest[5].(*sql.NullTime)) = sql.NullTime{  // end_date
-				Time:  expectedEndDate,
-				Valid: true,
-			}
+			*(dest[0].(*string)) = "sub-123"                               // id
+			*(dest[1].(*string)) = "Yandex Plus"                           // service_name
+			*(dest[2].(*entity.Amount)) = 1500                             // price
+			*(dest[3].(*string)) = userId                                  // user_id
+			*(dest[4].(*entity.YearMonth)) = expectedStartDate             // start_date
+			*(dest[5].(**entity.YearMonth)) = &expectedEndDate             // end_date
+			*(dest[6].(*entity.BillingPeriod)) = entity.BillingPeriodMonth // billing_period
+			*(dest[7].(*int)) = 1                                          // billing_interval
+			*(dest[8].(*string)) = "RUB"                                   // currency
 			return nil
 		})
 
@@ -68,8 +67,62 @@ func TestSubRepository_Create_Success(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "sub-123", result.Id)
 	assert.Equal(t, "Yandex Plus", result.Name)
-	assert.Equal(t, 1500, result.Price)
+	assert.Equal(t, entity.Amount(1500), result.Price.Amount)
 	assert.Equal(t, userId, result.UserId)
-	assert.Equal(t, "01-2025", result.StartDate)
-	assert.Equal(t, "12-2025", result.EndDate)
+	assert.Equal(t, "01-2025", result.StartDate.String())
+	assert.Equal(t, "12-2025", result.EndDate.String())
+	assert.Equal(t, entity.BillingPeriodMonth, result.BillingPeriod)
+	assert.Equal(t, 1, result.BillingInterval)
+	assert.Equal(t, "RUB", result.Price.Currency)
+}
+
+func TestSubRepository_Create_RecordsPrincipal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := auth.WithPrincipal(context.Background(), "alice@example.com")
+	createdAt := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	sub := &entity.Subscription{
+		Name:            "Yandex Plus",
+		Price:           entity.Money{Amount: 1500, Currency: "RUB"},
+		UserId:          "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+		StartDate:       yearMonth("01-2025"),
+		BillingPeriod:   entity.BillingPeriodMonth,
+		BillingInterval: 1,
+	}
+
+	mockDB.EXPECT().
+		QueryRow(
+			ctx,
+			gomock.Cond(func(sql any) bool {
+				return assert.Contains(t, sql, "created_by, updated_by") && assert.Contains(t, sql, "$9, $9")
+			}),
+			"Yandex Plus", entity.Amount(1500), sub.UserId, sub.StartDate, gomock.Nil(), entity.BillingPeriodMonth, 1, "RUB",
+			gomock.Cond(func(p any) bool { return *p.(*string) == "alice@example.com" }),
+		).
+		Return(mockRow)
+
+	mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*string)) = "sub-123"
+			*(dest[10].(*time.Time)) = createdAt // created_at
+			*(dest[11].(*time.Time)) = createdAt // updated_at
+			author := "alice@example.com"
+			*(dest[12].(**string)) = &author // created_by
+			*(dest[13].(**string)) = &author // updated_by
+			return nil
+		})
+
+	result, err := repo.Create(ctx, sub)
+
+	require.NoError(t, err)
+	assert.Equal(t, createdAt, result.CreatedAt)
+	assert.Equal(t, "alice@example.com", *result.CreatedBy)
+	assert.Equal(t, "alice@example.com", *result.UpdatedBy)
 }
@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"subscriptions/internal/entity"
+	"time"
+)
+
+type ratePoint struct {
+	month int
+	rate  entity.Rate
+}
+
+// currencyConverter переводит суммы в целевую валюту по курсу, действующему в месяце списания
+type currencyConverter struct {
+	target string
+	rates  map[string][]ratePoint
+}
+
+// newCurrencyConverter загружает курсы валют подписок, действующие в периоде.
+// Пустая целевая валюта означает базовую (RUB)
+func (r *subRepository) newCurrencyConverter(ctx context.Context, target string,
+	subs []periodSubscription, periodEnd time.Time) (*currencyConverter, error) {
+
+	if target == "" {
+		target = entity.BaseCurrency
+	}
+
+	conv := &currencyConverter{target: target, rates: make(map[string][]ratePoint)}
+
+	needed := make(map[string]struct{})
+	for _, ps := range subs {
+		if ps.sub.Price.Currency != target {
+			needed[ps.sub.Price.Currency] = struct{}{}
+			needed[target] = struct{}{}
+		}
+	}
+	delete(needed, entity.BaseCurrency)
+
+	// Все подписки уже в целевой валюте или конвертация идет только через рубль
+	if len(needed) == 0 {
+		return conv, nil
+	}
+
+	currencies := make([]string, 0, len(needed))
+	for currency := range needed {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	rows, err := r.db.Query(
+		ctx,
+		`SELECT currency, valid_from, rate
+		FROM exchange_rates
+		WHERE currency = ANY($1) AND valid_from <= $2
+		ORDER BY currency, valid_from`,
+		currencies,
+		entity.YearMonthOf(periodEnd),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load exchange rates: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var currency string
+		var validFrom entity.YearMonth
+		var rate entity.Rate
+
+		if err := rows.Scan(&currency, &validFrom, &rate); err != nil {
+			return nil, fmt.Errorf("failed to scan exchange rate: %w", err)
+		}
+
+		conv.rates[currency] = append(conv.rates[currency], ratePoint{month: monthIndex(validFrom.Time()), rate: rate})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load exchange rates: %w", err)
+	}
+
+	return conv, nil
+}
+
+// rate возвращает последний курс валюты, действующий в месяце month
+func (c *currencyConverter) rate(currency string, month int) (entity.Rate, error) {
+	if currency == entity.BaseCurrency {
+		return entity.BaseRate, nil
+	}
+
+	points := c.rates[currency]
+	i := sort.Search(len(points), func(i int) bool { return points[i].month > month }) - 1
+	if i < 0 {
+		return 0, fmt.Errorf("%w: %s in %s", entity.ErrExchangeRateNotFound, currency, entity.YearMonthOf(monthStart(month)))
+	}
+
+	return points[i].rate, nil
+}
+
+// convert переводит сумму списаний месяца month в целевую валюту с округлением до минимальных единиц
+func (c *currencyConverter) convert(amount entity.Money, month int) (entity.Money, error) {
+	if amount.Currency == c.target {
+		return amount, nil
+	}
+
+	from, err := c.rate(amount.Currency, month)
+	if err != nil {
+		return entity.Money{}, err
+	}
+
+	to, err := c.rate(c.target, month)
+	if err != nil {
+		return entity.Money{}, err
+	}
+
+	converted, err := amount.Amount.Convert(from, to)
+	if err != nil {
+		return entity.Money{}, err
+	}
+
+	return entity.Money{Amount: converted, Currency: c.target}, nil
+}
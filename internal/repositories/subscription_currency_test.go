@@ -0,0 +1,197 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func testMonth(year int, month time.Month) int {
+	return monthIndex(time.Date(year, month, 1, 0, 0, 0, 0, time.UTC))
+}
+
+// Фиксированные курсы для тестов: USD меняется с 03-2025, EUR задан один раз
+func testConverter(target string) *currencyConverter {
+	return &currencyConverter{
+		target: target,
+		rates: map[string][]ratePoint{
+			"USD": {
+				{month: testMonth(2025, time.January), rate: 100 * entity.BaseRate},
+				{month: testMonth(2025, time.March), rate: 80 * entity.BaseRate},
+			},
+			"EUR": {
+				{month: testMonth(2025, time.January), rate: 110 * entity.BaseRate},
+			},
+		},
+	}
+}
+
+func TestCurrencyConverter_Convert(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		amount   entity.Amount
+		currency string
+		month    int
+		expected entity.Amount
+	}{
+		{"same currency", "USD", 10, "USD", testMonth(2025, time.January), 10},
+		{"usd to rub", "RUB", 10, "USD", testMonth(2025, time.January), 1000},
+		{"usd to rub keeps previous rate", "RUB", 10, "USD", testMonth(2025, time.February), 1000},
+		{"usd to rub new rate", "RUB", 10, "USD", testMonth(2025, time.June), 800},
+		{"rub to usd", "USD", 399, "RUB", testMonth(2025, time.March), 5},
+		{"eur to usd cross rate", "USD", 10, "EUR", testMonth(2025, time.March), 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := testConverter(tt.target).convert(entity.Money{Amount: tt.amount, Currency: tt.currency}, tt.month)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, amount.Amount)
+			assert.Equal(t, tt.target, amount.Currency)
+		})
+	}
+}
+
+func TestCurrencyConverter_Convert_NoRate(t *testing.T) {
+	_, err := testConverter("RUB").convert(entity.Money{Amount: 10, Currency: "USD"}, testMonth(2024, time.December))
+
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, entity.ErrExchangeRateNotFound))
+	assert.Contains(t, err.Error(), "USD in 12-2024")
+}
+
+func TestSubRepository_CalculateSummary_ConvertsCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
+	mockRateRows := mocks.NewMockRows(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	userID := "user-123"
+	serviceName := "Netflix"
+
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Any(),
+			userID, serviceName, yearMonth("01-2025"), yearMonth("04-2025"),
+		).
+		Return(mockRows, nil)
+
+	expectPeriodRows(mockRows, []periodRow{
+		{
+			id:        "sub-1",
+			name:      serviceName,
+			price:     10,
+			userId:    userID,
+			startDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			currency:  "USD",
+		},
+	})
+
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Any(),
+			[]string{"USD"}, yearMonth("04-2025"),
+		).
+		Return(mockRateRows, nil)
+
+	rates := []struct {
+		validFrom time.Time
+		rate      entity.Rate
+	}{
+		{time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 100 * entity.BaseRate},
+		{time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), 80 * entity.BaseRate},
+	}
+
+	calls := []any{}
+	for _, rate := range rates {
+		calls = append(calls,
+			mockRateRows.EXPECT().Next().Return(true),
+			mockRateRows.EXPECT().
+				Scan(gomock.Any()).
+				DoAndReturn(func(dest ...interface{}) error {
+					*(dest[0].(*string)) = "USD"
+					*(dest[1].(*entity.YearMonth)) = entity.YearMonthOf(rate.validFrom)
+					*(dest[2].(*entity.Rate)) = rate.rate
+					return nil
+				}),
+		)
+	}
+	calls = append(calls, mockRateRows.EXPECT().Next().Return(false))
+	gomock.InOrder(calls...)
+	mockRateRows.EXPECT().Err().Return(nil).AnyTimes()
+	mockRateRows.EXPECT().Close().AnyTimes()
+
+	summary, err := repo.CalculateSummary(ctx, userID, serviceName, yearMonth("01-2025"), yearMonthPtr("04-2025"), "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "RUB", summary.TotalCost.Currency)
+	// 01-2025 и 02-2025 по курсу 100, 03-2025 и 04-2025 по курсу 80
+	assert.Equal(t, entity.Amount(3600), summary.TotalCost.Amount)
+	assert.Equal(t, 4, summary.Subscriptions[0].Charges)
+}
+
+func TestSubRepository_CalculateSummary_NoExchangeRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
+	mockRateRows := mocks.NewMockRows(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	userID := "user-123"
+	serviceName := "Yandex Plus"
+
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Any(),
+			userID, serviceName, yearMonth("01-2025"), yearMonth("12-2025"),
+		).
+		Return(mockRows, nil)
+
+	expectPeriodRows(mockRows, []periodRow{
+		{
+			id:        "sub-1",
+			name:      serviceName,
+			price:     400,
+			userId:    userID,
+			startDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	})
+
+	// Курсов EUR в базе нет
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Any(),
+			[]string{"EUR"}, yearMonth("12-2025"),
+		).
+		Return(mockRateRows, nil)
+
+	mockRateRows.EXPECT().Next().Return(false)
+	mockRateRows.EXPECT().Err().Return(nil).AnyTimes()
+	mockRateRows.EXPECT().Close().AnyTimes()
+
+	summary, err := repo.CalculateSummary(ctx, userID, serviceName, yearMonth("01-2025"), yearMonthPtr("12-2025"), "EUR")
+
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, entity.ErrExchangeRateNotFound))
+	assert.Nil(t, summary)
+}
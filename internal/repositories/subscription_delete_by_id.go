@@ -5,17 +5,27 @@ import (
 	"fmt"
 )
 
-func (r *subRepository) DeleteById(ctx context.Context, id string) error {
+// DeleteById мягко удаляет подписку: она пропадает из выборок, пока ее не восстановят или не очистят по сроку хранения.
+// Ненулевая version удаляет ее, только если подписка не менялась с этой версии
+func (r *subRepository) DeleteById(ctx context.Context, id string, version int64) error {
 
-	_, err := r.db.Exec(
+	tag, err := r.db.Exec(
 		ctx,
-		`DELETE FROM subscriptions 
-		WHERE id = $1`,
+		`UPDATE subscriptions 
+		SET deleted_at = now(), version = version + 1, updated_at = now(), updated_by = $3
+		WHERE id = $1 AND deleted_at IS NULL AND ($2::bigint = 0 OR version = $2)`,
 		id,
+		version,
+		principal(ctx),
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to DELETE subscription: %v", err)
+		return fmt.Errorf("failed to DELETE subscription: %w", err)
+	}
+
+	// Подписку удалили раньше, ее не было или ее версия другая: узнаем об этом из того же запроса
+	if tag.RowsAffected() == 0 {
+		return r.missingError(ctx, id, version, false)
 	}
 
 	return nil
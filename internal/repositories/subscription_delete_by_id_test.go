@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"subscriptions/internal/entity"
 	"subscriptions/internal/repositories/mocks"
 
 	"github.com/jackc/pgx/v5/pgconn"
@@ -21,17 +22,23 @@ func TestSubRepository_DeleteById_Success(t *testing.T) {
 	ctx := context.Background()
 	subscriptionID := "sub-123"
 
-	commandTag := pgconn.NewCommandTag("DELETE 1")
+	commandTag := pgconn.NewCommandTag("UPDATE 1")
 
+	// Удаление мягкое: строка остается в таблице с отметкой deleted_at
 	mockDB.EXPECT().
 		Exec(
 			ctx,
-			gomock.Any(),
+			gomock.Cond(func(sql any) bool {
+				return assert.Contains(t, sql, "SET deleted_at = now()") &&
+					assert.Contains(t, sql, "WHERE id = $1 AND deleted_at IS NULL")
+			}),
 			subscriptionID,
+			int64(0),
+			gomock.Nil(),
 		).
 		Return(commandTag, nil)
 
-	err := repo.DeleteById(ctx, subscriptionID)
+	err := repo.DeleteById(ctx, subscriptionID, 0)
 	assert.NoError(t, err)
 }
 
@@ -45,41 +52,105 @@ func TestSubRepository_DeleteById_NotFound(t *testing.T) {
 	ctx := context.Background()
 	subscriptionID := "non-existent-id"
 
-	commandTag := pgconn.NewCommandTag("DELETE 0")
+	commandTag := pgconn.NewCommandTag("UPDATE 0")
 
 	mockDB.EXPECT().
 		Exec(
 			ctx,
 			gomock.Any(),
 			subscriptionID,
+			int64(0),
+			gomock.Nil(),
 		).
 		Return(commandTag, nil)
 
-	err := repo.DeleteById(ctx, subscriptionID)
-	assert.NoError(t, err)
+	err := repo.DeleteById(ctx, subscriptionID, 0)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
 }
 
 func TestSubRepository_DeleteById_DBError(t *testing.T) {
-    ctrl := gomock.NewController(t)
-    defer ctrl.Finish()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	subscriptionID := "sub-123"
+
+	emptyCommandTag := pgconn.NewCommandTag("")
+
+	mockDB.EXPECT().
+		Exec(
+			ctx,
+			gomock.Any(),
+			subscriptionID,
+			int64(0),
+			gomock.Nil(),
+		).
+		Return(emptyCommandTag, assert.AnError)
+
+	err := repo.DeleteById(ctx, subscriptionID, 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to DELETE subscription")
+}
+
+func TestSubRepository_DeleteById_VersionMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-    mockDB := mocks.NewMockDB(ctrl)
-    repo := &subRepository{db: mockDB}
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
+	repo := &subRepository{db: mockDB}
 
-    ctx := context.Background()
-    subscriptionID := "sub-123"
+	ctx := context.Background()
+	subscriptionID := "sub-123"
 
-    emptyCommandTag := pgconn.NewCommandTag("")
+	mockDB.EXPECT().
+		Exec(ctx, gomock.Any(), subscriptionID, int64(2), gomock.Nil()).
+		Return(pgconn.NewCommandTag("UPDATE 0"), nil)
 
-    mockDB.EXPECT().
-        Exec(
-            ctx,
-            gomock.Any(),
-            subscriptionID,
-        ).
-        Return(emptyCommandTag, assert.AnError)
+	mockDB.EXPECT().
+		QueryRow(ctx, gomock.Any(), subscriptionID, false).
+		Return(mockRow)
+
+	mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*bool)) = true
+			return nil
+		})
+
+	err := repo.DeleteById(ctx, subscriptionID, 2)
+	assert.ErrorIs(t, err, entity.ErrVersionMismatch)
+}
 
-    err := repo.DeleteById(ctx, subscriptionID)
-    assert.Error(t, err)
-    assert.Contains(t, err.Error(), "failed to DELETE subscription")
+func TestSubRepository_DeleteById_VersionGivenNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	subscriptionID := "non-existent-id"
+
+	mockDB.EXPECT().
+		Exec(ctx, gomock.Any(), subscriptionID, int64(2), gomock.Nil()).
+		Return(pgconn.NewCommandTag("UPDATE 0"), nil)
+
+	mockDB.EXPECT().
+		QueryRow(ctx, gomock.Any(), subscriptionID, false).
+		Return(mockRow)
+
+	mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*bool)) = false
+			return nil
+		})
+
+	err := repo.DeleteById(ctx, subscriptionID, 2)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
 }
@@ -2,41 +2,30 @@ package repositories
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
 	"subscriptions/internal/entity"
-	"time"
+
+	"github.com/jackc/pgx/v5"
 )
 
 func (r *subRepository) GetById(ctx context.Context, id string) (*entity.Subscription, error) {
 
 	var sub entity.Subscription
 
-	var startDateDB time.Time
-	var endDateDB sql.NullTime
-
-	err := r.db.QueryRow(
+	row := r.db.QueryRow(
 		ctx,
-		`SELECT id, service_name, price, user_id, start_date, end_date
+		`SELECT `+subscriptionColumns+`
 		FROM subscriptions 
-		WHERE id = $1`,
+		WHERE id = $1 AND deleted_at IS NULL`,
 		id,
-	).Scan(&sub.Id, &sub.Name, &sub.Price, &sub.UserId, &startDateDB, &endDateDB)
+	)
 
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, err
+	if err := scanSubscription(row, &sub); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("subscription %s: %w", id, entity.ErrNotFound)
 		}
-		return nil, fmt.Errorf("failed to GET subscription: %v", err)
-	}
-
-	sub.StartDate = formatTimeToMMYYYY(startDateDB)
-
-	if endDateDB.Valid {
-		sub.EndDate = formatTimeToMMYYYY(endDateDB.Time)
-	} else {
-		sub.EndDate = ""
+		return nil, fmt.Errorf("failed to GET subscription: %w", err)
 	}
 
 	return &sub, nil
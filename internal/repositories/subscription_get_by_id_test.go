@@ -2,12 +2,12 @@ package repositories
 
 import (
 	"context"
-	"database/sql"
 	"errors"
+	"subscriptions/internal/entity"
 	"subscriptions/internal/repositories/mocks"
 	"testing"
-	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -27,28 +27,28 @@ func TestSubRepository_GetById_Success(t *testing.T) {
 	mockDB.EXPECT().
 		QueryRow(
 			ctx,
-			`SELECT id, service_name, price, user_id, start_date, end_date
+			`SELECT `+subscriptionColumns+`
 		FROM subscriptions 
-		WHERE id = $1`,
+		WHERE id = $1 AND deleted_at IS NULL`,
 			subscriptionID,
 		).
 		Return(mockRow)
 
-	expectedStartDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
-	expectedEndDate := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	expectedStartDate := yearMonth("01-2025")
+	expectedEndDate := yearMonth("12-2025")
 
 	mockRow.EXPECT().
 		Scan(gomock.Any()).
 		DoAndReturn(func(dest ...interface{}) error {
-			*(dest[0].(*string)) = "sub-123"            // id
-			*(dest[1].(*string)) = "Yandex Plus"        // service_name
-			*(dest[2].(*int)) = 1500                    // price
-			*(dest[3].(*string)) = "user-123"           // user_id
-			*(dest[4].(*time.Time)) = expectedStartDate // start_date
-			*(dest[5].(*sql.NullTime)) = sql.NullTime{  // end_date
-				Time:  expectedEndDate,
-				Valid: true,
-			}
+			*(dest[0].(*string)) = "sub-123"                               // id
+			*(dest[1].(*string)) = "Yandex Plus"                           // service_name
+			*(dest[2].(*entity.Amount)) = 1500                             // price
+			*(dest[3].(*string)) = "user-123"                              // user_id
+			*(dest[4].(*entity.YearMonth)) = expectedStartDate             // start_date
+			*(dest[5].(**entity.YearMonth)) = &expectedEndDate             // end_date
+			*(dest[6].(*entity.BillingPeriod)) = entity.BillingPeriodMonth // billing_period
+			*(dest[7].(*int)) = 1                                          // billing_interval
+			*(dest[8].(*string)) = "USD"                                   // currency
 			return nil
 		})
 
@@ -58,10 +58,13 @@ func TestSubRepository_GetById_Success(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, "sub-123", result.Id)
 	assert.Equal(t, "Yandex Plus", result.Name)
-	assert.Equal(t, 1500, result.Price)
+	assert.Equal(t, entity.Amount(1500), result.Price.Amount)
 	assert.Equal(t, "user-123", result.UserId)
-	assert.Equal(t, "01-2025", result.StartDate)
-	assert.Equal(t, "12-2025", result.EndDate)
+	assert.Equal(t, "01-2025", result.StartDate.String())
+	assert.Equal(t, "12-2025", result.EndDate.String())
+	assert.Equal(t, entity.BillingPeriodMonth, result.BillingPeriod)
+	assert.Equal(t, 1, result.BillingInterval)
+	assert.Equal(t, "USD", result.Price.Currency)
 }
 
 func TestSubRepository_GetById_WithoutEndDate(t *testing.T) {
@@ -78,24 +81,25 @@ func TestSubRepository_GetById_WithoutEndDate(t *testing.T) {
 	mockDB.EXPECT().
 		QueryRow(
 			ctx,
-			gomock.Any(), 
+			gomock.Any(),
 			subscriptionID,
 		).
 		Return(mockRow)
 
-	expectedStartDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	expectedStartDate := yearMonth("01-2025")
 
 	mockRow.EXPECT().
 		Scan(gomock.Any()).
 		DoAndReturn(func(dest ...interface{}) error {
-			*(dest[0].(*string)) = "sub-123"            // id
-			*(dest[1].(*string)) = "Yandex Plus"        // service_name
-			*(dest[2].(*int)) = 1500                    // price
-			*(dest[3].(*string)) = "user-123"           // user_id
-			*(dest[4].(*time.Time)) = expectedStartDate // start_date
-			*(dest[5].(*sql.NullTime)) = sql.NullTime{  // end_date
-				Valid: false,
-			}
+			*(dest[0].(*string)) = "sub-123"                              // id
+			*(dest[1].(*string)) = "Yandex Plus"                          // service_name
+			*(dest[2].(*entity.Amount)) = 1500                            // price
+			*(dest[3].(*string)) = "user-123"                             // user_id
+			*(dest[4].(*entity.YearMonth)) = expectedStartDate            // start_date
+			*(dest[5].(**entity.YearMonth)) = nil                         // end_date
+			*(dest[6].(*entity.BillingPeriod)) = entity.BillingPeriodYear // billing_period
+			*(dest[7].(*int)) = 1                                         // billing_interval
+			*(dest[8].(*string)) = "RUB"                                  // currency
 			return nil
 		})
 
@@ -105,10 +109,11 @@ func TestSubRepository_GetById_WithoutEndDate(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, "sub-123", result.Id)
 	assert.Equal(t, "Yandex Plus", result.Name)
-	assert.Equal(t, 1500, result.Price)
+	assert.Equal(t, entity.Amount(1500), result.Price.Amount)
 	assert.Equal(t, "user-123", result.UserId)
-	assert.Equal(t, "01-2025", result.StartDate)
-	assert.Equal(t, "", result.EndDate) 
+	assert.Equal(t, "01-2025", result.StartDate.String())
+	assert.Nil(t, result.EndDate)
+	assert.Equal(t, entity.BillingPeriodYear, result.BillingPeriod)
 }
 
 func TestSubRepository_GetById_NotFound(t *testing.T) {
@@ -132,12 +137,12 @@ func TestSubRepository_GetById_NotFound(t *testing.T) {
 
 	mockRow.EXPECT().
 		Scan(gomock.Any()).
-		Return(sql.ErrNoRows)
+		Return(pgx.ErrNoRows)
 
 	result, err := repo.GetById(ctx, subscriptionID)
 
 	assert.Error(t, err)
-	assert.True(t, errors.Is(err, sql.ErrNoRows))
+	assert.True(t, errors.Is(err, entity.ErrNotFound))
 	assert.Nil(t, result)
 }
 
@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"subscriptions/internal/entity"
+	"time"
+)
+
+// periodSubscription — подписка вместе с датами и изменениями цены, нужными для расчета стоимости
+type periodSubscription struct {
+	sub       entity.Subscription
+	startDate time.Time
+	endDate   *time.Time
+	// prices — изменения цены в порядке вступления в силу, до первого из них действует sub.Price
+	prices []pricePoint
+}
+
+type pricePoint struct {
+	month  int
+	amount entity.Amount
+}
+
+// priceIn возвращает цену одного списания, действующую в месяце month
+func (ps periodSubscription) priceIn(month int) entity.Money {
+	price := ps.sub.Price
+
+	i := sort.Search(len(ps.prices), func(i int) bool { return ps.prices[i].month > month }) - 1
+	if i >= 0 {
+		price.Amount = ps.prices[i].amount
+	}
+
+	return price
+}
+
+// parsePeriod возвращает границы периода расчета. Без end_date период считается до текущего месяца включительно
+func parsePeriod(startDate entity.YearMonth, endDate *entity.YearMonth) (time.Time, time.Time, error) {
+	if startDate.IsZero() {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start date: %w", entity.ErrInvalidYearMonth)
+	}
+
+	periodEnd := entity.CurrentYearMonth()
+	if endDate != nil {
+		periodEnd = *endDate
+	}
+
+	if periodEnd.Before(startDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid period: end date %s is before start date %s", periodEnd, startDate)
+	}
+
+	return startDate.Time(), periodEnd.Time(), nil
+}
+
+// getInPeriod возвращает подписки пользователя, которые хотя бы частично пересекаются с периодом.
+// Пустой serviceName означает все сервисы пользователя
+func (r *subRepository) getInPeriod(ctx context.Context, userID, serviceName string,
+	periodStart, periodEnd time.Time) ([]periodSubscription, error) {
+
+	// Изменения цены читаются тем же запросом двумя параллельными массивами, упорядоченными по месяцу
+	query := `
+		SELECT id, service_name, price, user_id, start_date, end_date, billing_period, billing_interval, currency,
+			ARRAY(SELECT effective_from FROM subscription_price_changes WHERE subscription_id = s.id ORDER BY effective_from),
+			ARRAY(SELECT price FROM subscription_price_changes WHERE subscription_id = s.id ORDER BY effective_from)
+		FROM subscriptions s
+		WHERE user_id = $1 AND deleted_at IS NULL
+	`
+
+	args := []interface{}{userID}
+	argIndex := 2
+
+	if serviceName != "" {
+		query += fmt.Sprintf(" AND service_name = $%d", argIndex)
+		args = append(args, serviceName)
+		argIndex++
+	}
+
+	query += fmt.Sprintf(" AND start_date <= $%d AND (end_date IS NULL OR end_date >= $%d)", argIndex+1, argIndex)
+	query += " ORDER BY start_date, id"
+	args = append(args, entity.YearMonthOf(periodStart), entity.YearMonthOf(periodEnd))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []periodSubscription
+	for rows.Next() {
+		var ps periodSubscription
+		var changeMonths []entity.YearMonth
+		var changePrices []entity.Amount
+
+		err := rows.Scan(&ps.sub.Id, &ps.sub.Name, &ps.sub.Price.Amount, &ps.sub.UserId, &ps.sub.StartDate, &ps.sub.EndDate,
+			&ps.sub.BillingPeriod, &ps.sub.BillingInterval, &ps.sub.Price.Currency, &changeMonths, &changePrices)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		if len(changeMonths) != len(changePrices) {
+			return nil, fmt.Errorf("subscription %s: inconsistent price changes", ps.sub.Id)
+		}
+
+		for i, month := range changeMonths {
+			ps.prices = append(ps.prices, pricePoint{month: monthIndex(month.Time()), amount: changePrices[i]})
+		}
+
+		ps.startDate = ps.sub.StartDate.Time()
+		if ps.sub.EndDate != nil {
+			endDate := ps.sub.EndDate.Time()
+			ps.endDate = &endDate
+		}
+
+		subs = append(subs, ps)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subs, nil
+}
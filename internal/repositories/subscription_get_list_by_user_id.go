@@ -2,36 +2,142 @@ package repositories
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
+	"strings"
 	"subscriptions/internal/entity"
-	"time"
 )
 
-func (r *subRepository) GetList(ctx context.Context, offset, limit int, userID, serviceName string) ([]entity.Subscription, error) {
+// sortColumn — выражение и тип ключа сортировки. Тип нужен, чтобы привести к нему значение из курсора
+type sortColumn struct {
+	expr    string
+	sqlType string
+}
+
+// Подписки без даты окончания считаются бессрочными: при сортировке по end_date они идут после остальных
+var sortColumns = map[string]sortColumn{
+	entity.SortKeyPrice:       {"price", "bigint"},
+	entity.SortKeyStartDate:   {"start_date", "date"},
+	entity.SortKeyEndDate:     {"COALESCE(end_date, 'infinity'::date)", "date"},
+	entity.SortKeyServiceName: {"service_name", "text"},
+	entity.SortKeyUpdatedAt:   {"updated_at", "timestamptz"},
+}
+
+// likeEscaper экранирует спецсимволы LIKE, чтобы префикс искался буквально
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// listConditions строит условия WHERE по фильтру списка. Параметры нумеруются с $1
+func listConditions(filter entity.SubscriptionFilter) (string, []interface{}) {
+	conds := ""
+	args := []interface{}{}
+
+	// where добавляет условие, подставляя в него номер очередного параметра
+	where := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds += " AND " + fmt.Sprintf(cond, len(args))
+	}
+
+	if !filter.IncludeDeleted {
+		conds += " AND deleted_at IS NULL"
+	}
+
+	if filter.UserId != "" {
+		where("user_id = $%d", filter.UserId)
+	}
+
+	if filter.ServiceName != "" {
+		where("service_name = $%d", filter.ServiceName)
+	}
+
+	// Поиск по префиксу использует индекс по lower(service_name) text_pattern_ops
+	if filter.ServiceNamePrefix != "" {
+		where(`lower(service_name) LIKE lower($%d) || '%%'`, likeEscaper.Replace(filter.ServiceNamePrefix))
+	}
+
+	if filter.PriceMin != nil {
+		where("price >= $%d", *filter.PriceMin)
+	}
+
+	if filter.PriceMax != nil {
+		where("price <= $%d", *filter.PriceMax)
+	}
+
+	if filter.ActiveAt != nil {
+		where("start_date <= $%d", *filter.ActiveAt)
+		where("(end_date IS NULL OR end_date >= $%d)", *filter.ActiveAt)
+	}
+
+	if filter.StartFrom != nil {
+		where("start_date >= $%d", *filter.StartFrom)
+	}
+
+	if filter.StartTo != nil {
+		where("start_date <= $%d", *filter.StartTo)
+	}
+
+	// Граница включается: запись, измененная в ту же микросекунду, лучше придет повторно, чем потеряется
+	if filter.UpdatedSince != nil {
+		where("updated_at >= $%d", *filter.UpdatedSince)
+	}
+
+	switch filter.Status {
+	case entity.StatusActive:
+		where("(end_date IS NULL OR end_date >= $%d)", entity.CurrentYearMonth())
+	case entity.StatusEnded:
+		where("end_date < $%d", entity.CurrentYearMonth())
+	}
+
+	return conds, args
+}
+
+func (r *subRepository) GetList(ctx context.Context, filter entity.SubscriptionFilter, sort entity.Sort, page entity.ListPage) ([]entity.Subscription, error) {
+	conds, args := listConditions(filter)
+
 	query := `
-		SELECT id, service_name, price, user_id, start_date, end_date
+		SELECT ` + subscriptionColumns + `
 		FROM subscriptions
 		WHERE 1=1
-	`
+	` + conds
 
-	args := []interface{}{}
-	argIndex := 1
+	argIndex := len(args) + 1
+
+	// id замыкает порядок, поэтому записи с равным ключом сортировки не теряются между страницами
+	direction, cmp := "ASC", ">"
+	if sort.Order == entity.SortDesc {
+		direction, cmp = "DESC", "<"
+	}
 
-	if userID != "" {
-		query += fmt.Sprintf(" AND user_id = $%d", argIndex)
-		args = append(args, userID)
-		argIndex++
+	column, byColumn := sortColumns[sort.Key]
+
+	// Keyset-пагинация: следующая страница начинается сразу после последней записи предыдущей,
+	// поэтому глубина страницы не влияет на скорость, а вставки не сдвигают уже прочитанные записи
+	if page.After != nil {
+		if byColumn {
+			query += fmt.Sprintf(" AND (%s, id) %s ($%d::text::%s, $%d)",
+				column.expr, cmp, argIndex, column.sqlType, argIndex+1)
+			args = append(args, page.After.LastValue, page.After.LastId)
+			argIndex += 2
+		} else {
+			query += fmt.Sprintf(" AND id %s $%d", cmp, argIndex)
+			args = append(args, page.After.LastId)
+			argIndex++
+		}
 	}
 
-	if serviceName != "" {
-		query += fmt.Sprintf(" AND service_name = $%d", argIndex)
-		args = append(args, serviceName)
-		argIndex++
+	if byColumn {
+		query += fmt.Sprintf(" ORDER BY %s %s, id %s", column.expr, direction, direction)
+	} else {
+		query += " ORDER BY id " + direction
 	}
 
-	query += fmt.Sprintf(" ORDER BY id LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
-	args = append(args, limit, offset)
+	query += fmt.Sprintf(" LIMIT $%d", argIndex)
+	args = append(args, page.Limit)
+	argIndex++
+
+	// Устаревший режим page/limit
+	if page.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, page.Offset)
+	}
 
 	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
@@ -39,27 +145,38 @@ func (r *subRepository) GetList(ctx context.Context, offset, limit int, userID,
 	}
 	defer rows.Close()
 
-	var subs []entity.Subscription
+	// Пустая страница — не ошибка: у пользователя может не быть подписок, а последняя страница может оказаться пустой
+	subs := []entity.Subscription{}
 	for rows.Next() {
-		var startDateDB time.Time
-		var endDateDB sql.NullTime
 		var s entity.Subscription
-		err := rows.Scan(&s.Id, &s.Name, &s.Price, &s.UserId, &startDateDB, &endDateDB)
-		if err != nil {
+		if err := scanSubscription(rows, &s); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 
-		s.StartDate = formatTimeToMMYYYY(startDateDB)
-		if endDateDB.Valid {
-			s.EndDate = formatTimeToMMYYYY(endDateDB.Time)
-		}
-
 		subs = append(subs, s)
 	}
 
-	if len(subs) == 0 {
-		return subs, sql.ErrNoRows
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
 	}
-	
+
 	return subs, nil
 }
+
+// CountList возвращает число подписок, подходящих под фильтр, без учета пагинации
+func (r *subRepository) CountList(ctx context.Context, filter entity.SubscriptionFilter) (int, error) {
+	conds, args := listConditions(filter)
+
+	query := `
+		SELECT count(*)
+		FROM subscriptions
+		WHERE 1=1
+	` + conds
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count subscriptions: %w", err)
+	}
+
+	return count, nil
+}
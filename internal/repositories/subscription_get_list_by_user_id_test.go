@@ -2,10 +2,13 @@ package repositories
 
 import (
 	"context"
+	"subscriptions/internal/entity"
 	"subscriptions/internal/repositories/mocks"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -17,7 +20,7 @@ func TestSubRepository_GetList_QueryError(t *testing.T) {
 	repo := &subRepository{db: mockDB}
 
 	ctx := context.Background()
-	offset := 0
+	offset := 20
 	limit := 10
 
 	mockDB.EXPECT().
@@ -28,8 +31,191 @@ func TestSubRepository_GetList_QueryError(t *testing.T) {
 		).
 		Return(nil, assert.AnError)
 
-	result, err := repo.GetList(ctx, offset, limit, "", "")
+	result, err := repo.GetList(ctx, entity.SubscriptionFilter{}, entity.Sort{}, entity.ListPage{Limit: limit, Offset: offset})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
 }
+
+func TestSubRepository_GetList_AfterCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	userID := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	lastId := "550e8400-e29b-41d4-a716-446655440000"
+	limit := 10
+
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Cond(func(sql any) bool {
+				return assert.Contains(t, sql, "AND id > $2") && assert.NotContains(t, sql, "OFFSET")
+			}),
+			userID, lastId, limit,
+		).
+		Return(nil, assert.AnError)
+
+	page := entity.ListPage{Limit: limit, After: &entity.Cursor{SortKey: entity.SortKeyId, Order: entity.SortAsc, LastId: lastId}}
+	_, err := repo.GetList(ctx, entity.SubscriptionFilter{UserId: userID}, entity.Sort{Key: entity.SortKeyId, Order: entity.SortAsc}, page)
+
+	assert.Error(t, err)
+}
+
+func TestSubRepository_GetList_FiltersAndSortAfterCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	priceMin := entity.Amount(10000)
+	startFrom := entity.YearMonth{Year: 2025, Month: 1}
+	lastId := "550e8400-e29b-41d4-a716-446655440000"
+
+	filter := entity.SubscriptionFilter{
+		ServiceNamePrefix: "ya_",
+		PriceMin:          &priceMin,
+		StartFrom:         &startFrom,
+	}
+	sort := entity.Sort{Key: entity.SortKeyEndDate, Order: entity.SortDesc}
+	page := entity.ListPage{Limit: 11, After: &entity.Cursor{SortKey: entity.SortKeyEndDate, Order: entity.SortDesc, LastValue: "infinity", LastId: lastId}}
+
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Cond(func(sql any) bool {
+				return assert.Contains(t, sql, "AND deleted_at IS NULL") &&
+					assert.Contains(t, sql, `lower(service_name) LIKE lower($1) || '%'`) &&
+					assert.Contains(t, sql, "price >= $2") &&
+					assert.Contains(t, sql, "start_date >= $3") &&
+					assert.Contains(t, sql, "(COALESCE(end_date, 'infinity'::date), id) < ($4::text::date, $5)") &&
+					assert.Contains(t, sql, "ORDER BY COALESCE(end_date, 'infinity'::date) DESC, id DESC LIMIT $6")
+			}),
+			`ya\_`, priceMin, startFrom, "infinity", lastId, 11,
+		).
+		Return(nil, assert.AnError)
+
+	_, err := repo.GetList(ctx, filter, sort, page)
+
+	assert.Error(t, err)
+}
+
+func TestSubRepository_GetList_UpdatedSinceSortedByUpdatedAt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	since := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	lastUpdated := "2025-07-02T10:15:00.123456Z"
+	lastId := "550e8400-e29b-41d4-a716-446655440000"
+
+	sort := entity.Sort{Key: entity.SortKeyUpdatedAt, Order: entity.SortAsc}
+	page := entity.ListPage{Limit: 11, After: &entity.Cursor{SortKey: entity.SortKeyUpdatedAt, Order: entity.SortAsc, LastValue: lastUpdated, LastId: lastId}}
+
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Cond(func(sql any) bool {
+				return assert.Contains(t, sql, "updated_at >= $1") &&
+					assert.Contains(t, sql, "(updated_at, id) > ($2::text::timestamptz, $3)") &&
+					assert.Contains(t, sql, "ORDER BY updated_at ASC, id ASC LIMIT $4")
+			}),
+			since, lastUpdated, lastId, 11,
+		).
+		Return(nil, assert.AnError)
+
+	_, err := repo.GetList(ctx, entity.SubscriptionFilter{UpdatedSince: &since}, sort, page)
+
+	assert.Error(t, err)
+}
+
+func TestSubRepository_GetList_IncludeDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Cond(func(sql any) bool {
+				return assert.NotContains(t, sql, "deleted_at IS NULL")
+			}),
+			10,
+		).
+		Return(nil, assert.AnError)
+
+	_, err := repo.GetList(ctx, entity.SubscriptionFilter{IncludeDeleted: true}, entity.Sort{}, entity.ListPage{Limit: 10})
+
+	assert.Error(t, err)
+}
+
+func TestSubRepository_GetList_EmptyPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+
+	mockDB.EXPECT().
+		Query(ctx, gomock.Any(), 10).
+		Return(mockRows, nil)
+
+	mockRows.EXPECT().Next().Return(false)
+	mockRows.EXPECT().Err().Return(nil)
+	mockRows.EXPECT().Close()
+
+	subs, err := repo.GetList(ctx, entity.SubscriptionFilter{}, entity.Sort{}, entity.ListPage{Limit: 10})
+
+	require.NoError(t, err)
+	assert.NotNil(t, subs)
+	assert.Empty(t, subs)
+}
+
+func TestSubRepository_CountList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	userID := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	mockDB.EXPECT().
+		QueryRow(
+			ctx,
+			gomock.Cond(func(sql any) bool {
+				return assert.Contains(t, sql, "SELECT count(*)") && assert.Contains(t, sql, "user_id = $1")
+			}),
+			userID,
+		).
+		Return(mockRow)
+
+	mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*int)) = 42
+			return nil
+		})
+
+	count, err := repo.CountList(ctx, entity.SubscriptionFilter{UserId: userID})
+
+	require.NoError(t, err)
+	assert.Equal(t, 42, count)
+}
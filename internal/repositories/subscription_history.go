@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"subscriptions/internal/entity"
+	"time"
+)
+
+// subscriptionSnapshot — строка подписки в том виде, в котором ее сохраняет в журнал to_jsonb
+type subscriptionSnapshot struct {
+	Id              string     `json:"id"`
+	ServiceName     string     `json:"service_name"`
+	Price           int64      `json:"price"`
+	UserId          string     `json:"user_id"`
+	StartDate       string     `json:"start_date"`
+	EndDate         *string    `json:"end_date"`
+	BillingPeriod   string     `json:"billing_period"`
+	BillingInterval int        `json:"billing_interval"`
+	Currency        string     `json:"currency"`
+	Version         int64      `json:"version"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	CreatedBy       *string    `json:"created_by"`
+	UpdatedBy       *string    `json:"updated_by"`
+	DeletedAt       *time.Time `json:"deleted_at"`
+}
+
+// decodeSnapshot переводит снимок из журнала в подписку. Пустой снимок означает, что подписки в этот момент не было
+func decodeSnapshot(data []byte) (*entity.Subscription, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var snap subscriptionSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, err
+	}
+
+	startDate, err := time.Parse(time.DateOnly, snap.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &entity.Subscription{
+		Id:              snap.Id,
+		Name:            snap.ServiceName,
+		Price:           entity.Money{Amount: entity.Amount(snap.Price), Currency: snap.Currency},
+		UserId:          snap.UserId,
+		StartDate:       entity.YearMonthOf(startDate),
+		BillingPeriod:   entity.BillingPeriod(snap.BillingPeriod),
+		BillingInterval: snap.BillingInterval,
+		Version:         snap.Version,
+		CreatedAt:       snap.CreatedAt,
+		UpdatedAt:       snap.UpdatedAt,
+		CreatedBy:       snap.CreatedBy,
+		UpdatedBy:       snap.UpdatedBy,
+		DeletedAt:       snap.DeletedAt,
+	}
+
+	if snap.EndDate != nil {
+		endDate, err := time.Parse(time.DateOnly, *snap.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		ym := entity.YearMonthOf(endDate)
+		sub.EndDate = &ym
+	}
+
+	return sub, nil
+}
+
+// priceChangeSnapshot — строка изменения цены в том виде, в котором ее сохраняет в журнал to_jsonb
+type priceChangeSnapshot struct {
+	SubscriptionId string    `json:"subscription_id"`
+	EffectiveFrom  string    `json:"effective_from"`
+	Price          int64     `json:"price"`
+	CreatedAt      time.Time `json:"created_at"`
+	CreatedBy      *string   `json:"created_by"`
+}
+
+// decodePriceChange переводит изменение цены из журнала. Пусто у всех событий, кроме изменения цены
+func decodePriceChange(data []byte) (*entity.PriceChange, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var snap priceChangeSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, err
+	}
+
+	effectiveFrom, err := time.Parse(time.DateOnly, snap.EffectiveFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.PriceChange{
+		SubscriptionId: snap.SubscriptionId,
+		EffectiveFrom:  entity.YearMonthOf(effectiveFrom),
+		Price:          entity.Amount(snap.Price),
+		CreatedAt:      snap.CreatedAt,
+		CreatedBy:      snap.CreatedBy,
+	}, nil
+}
+
+// GetHistory возвращает события журнала подписки от новых к старым
+func (r *subRepository) GetHistory(ctx context.Context, id string, page entity.HistoryPage) ([]entity.SubscriptionEvent, error) {
+	query := `
+		SELECT id, subscription_id, event_type, actor, occurred_at, before, after, price_change
+		FROM subscription_events
+		WHERE subscription_id = $1`
+	args := []interface{}{id}
+
+	if page.BeforeId > 0 {
+		args = append(args, page.BeforeId)
+		query += fmt.Sprintf(" AND id < $%d", len(args))
+	}
+
+	args = append(args, page.Limit)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET subscription history: %w", err)
+	}
+	defer rows.Close()
+
+	events := []entity.SubscriptionEvent{}
+	for rows.Next() {
+		var e entity.SubscriptionEvent
+		var before, after, priceChange []byte
+
+		if err := rows.Scan(&e.Id, &e.SubscriptionId, &e.Type, &e.Actor, &e.OccurredAt, &before, &after, &priceChange); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		if e.Before, err = decodeSnapshot(before); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot of event %d: %w", e.Id, err)
+		}
+		if e.After, err = decodeSnapshot(after); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot of event %d: %w", e.Id, err)
+		}
+		if e.PriceChange, err = decodePriceChange(priceChange); err != nil {
+			return nil, fmt.Errorf("failed to decode price change of event %d: %w", e.Id, err)
+		}
+
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"context"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSubRepository_GetHistory_DecodesSnapshots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	subId := "550e8400-e29b-41d4-a716-446655440000"
+
+	// Снимки в том виде, в котором их пишет to_jsonb в триггере
+	before := []byte(`{"id": "550e8400-e29b-41d4-a716-446655440000", "service_name": "Yandex Plus", "price": 29900,
+		"user_id": "60601fee-2bf1-4721-ae6f-7636e79a0cba", "start_date": "2025-01-01", "end_date": null,
+		"billing_period": "month", "billing_interval": 1, "currency": "RUB", "version": 1,
+		"created_at": "2025-01-10T09:00:00.123456+00:00", "updated_at": "2025-01-10T09:00:00.123456+00:00",
+		"created_by": "alice", "updated_by": "alice", "deleted_at": null}`)
+	after := []byte(`{"id": "550e8400-e29b-41d4-a716-446655440000", "service_name": "Yandex Plus", "price": 39900,
+		"user_id": "60601fee-2bf1-4721-ae6f-7636e79a0cba", "start_date": "2025-01-01", "end_date": "2025-12-01",
+		"billing_period": "month", "billing_interval": 1, "currency": "RUB", "version": 2,
+		"created_at": "2025-01-10T09:00:00.123456+00:00", "updated_at": "2025-03-05T14:30:00+00:00",
+		"created_by": "alice", "updated_by": "bob", "deleted_at": null}`)
+
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Cond(func(sql any) bool {
+				return assert.Contains(t, sql, "WHERE subscription_id = $1 AND id < $2 ORDER BY id DESC LIMIT $3")
+			}),
+			subId, int64(100), 21,
+		).
+		Return(mockRows, nil)
+
+	gomock.InOrder(
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*int64)) = 42
+			*(dest[1].(*string)) = subId
+			*(dest[2].(*entity.SubscriptionEventType)) = entity.EventUpdated
+			actor := "bob"
+			*(dest[3].(**string)) = &actor
+			*(dest[5].(*[]byte)) = before
+			*(dest[6].(*[]byte)) = after
+			return nil
+		}),
+		mockRows.EXPECT().Next().Return(false),
+		mockRows.EXPECT().Err().Return(nil),
+	)
+	mockRows.EXPECT().Close()
+
+	events, err := repo.GetHistory(ctx, subId, entity.HistoryPage{Limit: 21, BeforeId: 100})
+
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	event := events[0]
+	assert.Equal(t, entity.EventUpdated, event.Type)
+	assert.Equal(t, "bob", *event.Actor)
+	assert.Equal(t, entity.Amount(29900), event.Before.Price.Amount)
+	assert.Nil(t, event.Before.EndDate)
+	assert.Equal(t, entity.Amount(39900), event.After.Price.Amount)
+	assert.Equal(t, "12-2025", event.After.EndDate.String())
+	assert.Equal(t, "01-2025", event.After.StartDate.String())
+	assert.Equal(t, int64(2), event.After.Version)
+	assert.Equal(t, "bob", *event.After.UpdatedBy)
+}
+
+func TestSubRepository_GetHistory_CreatedEventHasNoBefore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	subId := "550e8400-e29b-41d4-a716-446655440000"
+
+	mockDB.EXPECT().
+		Query(
+			ctx,
+			gomock.Cond(func(sql any) bool {
+				return assert.NotContains(t, sql, "id <")
+			}),
+			subId, 11,
+		).
+		Return(mockRows, nil)
+
+	gomock.InOrder(
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*int64)) = 1
+			*(dest[2].(*entity.SubscriptionEventType)) = entity.EventCreated
+			*(dest[6].(*[]byte)) = []byte(`{"id": "550e8400-e29b-41d4-a716-446655440000", "start_date": "2025-01-01", "price": 100}`)
+			return nil
+		}),
+		mockRows.EXPECT().Next().Return(false),
+		mockRows.EXPECT().Err().Return(nil),
+	)
+	mockRows.EXPECT().Close()
+
+	events, err := repo.GetHistory(ctx, subId, entity.HistoryPage{Limit: 11})
+
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Nil(t, events[0].Before)
+	assert.Equal(t, entity.Amount(100), events[0].After.Price.Amount)
+}
+
+func TestSubRepository_GetHistory_DecodesPriceChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	subId := "550e8400-e29b-41d4-a716-446655440000"
+
+	mockDB.EXPECT().
+		Query(ctx, gomock.Cond(func(sql any) bool {
+			return assert.Contains(t, sql, "price_change")
+		}), subId, 11).
+		Return(mockRows, nil)
+
+	gomock.InOrder(
+		mockRows.EXPECT().Next().Return(true),
+		mockRows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*int64)) = 7
+			*(dest[2].(*entity.SubscriptionEventType)) = entity.EventPriceChanged
+			*(dest[7].(*[]byte)) = []byte(`{"subscription_id": "550e8400-e29b-41d4-a716-446655440000",
+				"effective_from": "2025-04-01", "price": 39900, "created_at": "2025-03-20T10:00:00Z", "created_by": "alice"}`)
+			return nil
+		}),
+		mockRows.EXPECT().Next().Return(false),
+		mockRows.EXPECT().Err().Return(nil),
+	)
+	mockRows.EXPECT().Close()
+
+	events, err := repo.GetHistory(ctx, subId, entity.HistoryPage{Limit: 11})
+
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Nil(t, events[0].Before)
+	assert.Nil(t, events[0].After)
+	require.NotNil(t, events[0].PriceChange)
+	assert.Equal(t, "04-2025", events[0].PriceChange.EffectiveFrom.String())
+	assert.Equal(t, entity.Amount(39900), events[0].PriceChange.Price)
+	assert.Equal(t, "alice", *events[0].PriceChange.CreatedBy)
+}
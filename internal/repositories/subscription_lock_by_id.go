@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"subscriptions/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// LockById читает действующую подписку и блокирует ее строку до конца транзакции. Пока блокировка
+// держится, AddPriceChange ждет, поэтому проверки по изменениям цены не устаревают до записи
+func (r *subRepository) LockById(ctx context.Context, id string) (*entity.Subscription, error) {
+
+	var sub entity.Subscription
+
+	row := r.db.QueryRow(
+		ctx,
+		`SELECT `+subscriptionColumns+`
+		FROM subscriptions
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE`,
+		id,
+	)
+
+	if err := scanSubscription(row, &sub); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("subscription %s: %w", id, entity.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to lock subscription: %w", err)
+	}
+
+	return &sub, nil
+}
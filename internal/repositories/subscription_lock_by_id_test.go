@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSubRepository_LockById_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+
+	mockDB.EXPECT().
+		QueryRow(ctx, gomock.Cond(func(sql any) bool {
+			return assert.Contains(t, sql, "WHERE id = $1 AND deleted_at IS NULL") &&
+				assert.Contains(t, sql, "FOR UPDATE")
+		}), "sub-123").
+		Return(mockRow)
+
+	mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*string)) = "sub-123"
+			*(dest[4].(*entity.YearMonth)) = yearMonth("01-2025")
+			return nil
+		})
+
+	result, err := repo.LockById(ctx, "sub-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, "sub-123", result.Id)
+	assert.Equal(t, "01-2025", result.StartDate.String())
+}
+
+func TestSubRepository_LockById_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+
+	mockDB.EXPECT().QueryRow(ctx, gomock.Any(), "sub-123").Return(mockRow)
+	mockRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows)
+
+	result, err := repo.LockById(ctx, "sub-123")
+
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+	assert.Nil(t, result)
+}
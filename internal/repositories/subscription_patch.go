@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"subscriptions/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Patch обновляет только переданные в патче колонки и возвращает подписку после изменения.
+// Ненулевая version применяет патч, только если подписка не менялась с этой версии
+func (r *subRepository) Patch(ctx context.Context, id string, patch *entity.SubscriptionPatch, version int64) (*entity.Subscription, error) {
+	sets := []string{}
+	args := []interface{}{id}
+
+	set := func(column string, value interface{}) {
+		args = append(args, value)
+		sets = append(sets, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if patch.Name != nil {
+		set("service_name", *patch.Name)
+	}
+	if patch.Price != nil {
+		set("price", *patch.Price)
+	}
+	if patch.Currency != nil {
+		set("currency", *patch.Currency)
+	}
+	if patch.UserId != nil {
+		set("user_id", *patch.UserId)
+	}
+	if patch.StartDate != nil {
+		set("start_date", *patch.StartDate)
+	}
+	if patch.EndDate != nil {
+		set("end_date", *patch.EndDate)
+	}
+	if patch.ClearEndDate {
+		sets = append(sets, "end_date = NULL")
+	}
+	if patch.BillingPeriod != nil {
+		set("billing_period", *patch.BillingPeriod)
+	}
+	if patch.BillingInterval != nil {
+		set("billing_interval", *patch.BillingInterval)
+	}
+
+	if len(sets) == 0 {
+		return nil, errors.New("failed to PATCH subscription: nothing to update")
+	}
+
+	sets = append(sets, "version = version + 1", "updated_at = now()")
+	set("updated_by", principal(ctx))
+	args = append(args, version)
+	where := fmt.Sprintf("id = $1 AND deleted_at IS NULL AND ($%d::bigint = 0 OR version = $%d)", len(args), len(args))
+
+	var sub entity.Subscription
+
+	row := r.db.QueryRow(
+		ctx,
+		`UPDATE subscriptions
+		SET `+strings.Join(sets, ", ")+`
+		WHERE `+where+`
+		RETURNING `+subscriptionColumns,
+		args...,
+	)
+
+	if err := scanSubscription(row, &sub); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, r.missingError(ctx, id, version, false)
+		}
+		return nil, fmt.Errorf("failed to PATCH subscription: %w", err)
+	}
+
+	return &sub, nil
+}
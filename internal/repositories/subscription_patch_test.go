@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSubRepository_Patch_OnlyProvidedColumns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	price := entity.Amount(29900)
+	patch := &entity.SubscriptionPatch{Price: &price, ClearEndDate: true}
+
+	mockDB.EXPECT().
+		QueryRow(
+			ctx,
+			gomock.Cond(func(sql any) bool {
+				return assert.Contains(t, sql, "SET price = $2, end_date = NULL, version = version + 1, updated_at = now(), updated_by = $3\n") &&
+					assert.Contains(t, sql, "WHERE id = $1 AND deleted_at IS NULL AND ($4::bigint = 0 OR version = $4)") &&
+					assert.Contains(t, sql, "RETURNING id, service_name")
+			}),
+			"sub-123", price, gomock.Nil(), int64(0),
+		).
+		Return(mockRow)
+
+	mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*string)) = "sub-123"
+			*(dest[2].(*entity.Amount)) = price
+			return nil
+		})
+
+	sub, err := repo.Patch(ctx, "sub-123", patch, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, "sub-123", sub.Id)
+	assert.Equal(t, price, sub.Price.Amount)
+	assert.Nil(t, sub.EndDate)
+}
+
+func TestSubRepository_Patch_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	name := "Yandex Plus"
+
+	mockDB.EXPECT().
+		QueryRow(ctx, gomock.Any(), "sub-123", name, gomock.Nil(), int64(0)).
+		Return(mockRow)
+
+	mockRow.EXPECT().
+		Scan(gomock.Any()).
+		Return(pgx.ErrNoRows)
+
+	sub, err := repo.Patch(ctx, "sub-123", &entity.SubscriptionPatch{Name: &name}, 0)
+
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+	assert.Nil(t, sub)
+}
+
+func TestSubRepository_Patch_VersionMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
+	mockExistsRow := mocks.NewMockRow(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	name := "Yandex Plus"
+
+	mockDB.EXPECT().
+		QueryRow(ctx, gomock.Any(), "sub-123", name, gomock.Nil(), int64(3)).
+		Return(mockRow)
+
+	mockRow.EXPECT().
+		Scan(gomock.Any()).
+		Return(pgx.ErrNoRows)
+
+	// Подписка есть, значит ее версия уже не 3
+	mockDB.EXPECT().
+		QueryRow(ctx, gomock.Any(), "sub-123", false).
+		Return(mockExistsRow)
+
+	mockExistsRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*bool)) = true
+			return nil
+		})
+
+	sub, err := repo.Patch(ctx, "sub-123", &entity.SubscriptionPatch{Name: &name}, 3)
+
+	assert.ErrorIs(t, err, entity.ErrVersionMismatch)
+	assert.Nil(t, sub)
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"subscriptions/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// AddPriceChange сохраняет изменение цены действующей подписки. Месяц изменения должен быть позже
+// start_date и не позже end_date, иначе возвращается entity.ErrOutsideTerm. Повторное изменение
+// с того же месяца нарушает первичный ключ и возвращается как ошибка уникальности
+func (r *subRepository) AddPriceChange(ctx context.Context, change *entity.PriceChange) (*entity.PriceChange, error) {
+	var out entity.PriceChange
+
+	// Сроки проверяются в том же запросе. FOR SHARE ждет транзакцию, которая меняет или заблокировала
+	// подписку, и проверяет условие уже на ее новой версии, поэтому PUT или PATCH не сдвинут
+	// сроки между проверкой и записью
+	err := r.db.QueryRow(
+		ctx,
+		`INSERT INTO subscription_price_changes (subscription_id, effective_from, price, created_by)
+		SELECT id, $2, $3, $4
+		FROM subscriptions
+		WHERE id = $1 AND deleted_at IS NULL
+		AND start_date < $2 AND (end_date IS NULL OR end_date >= $2)
+		FOR SHARE
+		RETURNING subscription_id, effective_from, price, created_at, created_by`,
+		change.SubscriptionId,
+		change.EffectiveFrom,
+		change.Price,
+		principal(ctx),
+	).Scan(&out.SubscriptionId, &out.EffectiveFrom, &out.Price, &out.CreatedAt, &out.CreatedBy)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, r.priceChangeRejected(ctx, change.SubscriptionId)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to INSERT price change: %w", err)
+	}
+
+	return &out, nil
+}
+
+// priceChangeRejected объясняет, почему AddPriceChange ничего не вставил: подписки нет
+// или месяц изменения вне ее срока
+func (r *subRepository) priceChangeRejected(ctx context.Context, id string) error {
+	var exists bool
+	err := r.db.QueryRow(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM subscriptions WHERE id = $1 AND deleted_at IS NULL)`,
+		id,
+	).Scan(&exists)
+
+	if err != nil {
+		return fmt.Errorf("failed to check subscription: %w", err)
+	}
+
+	if exists {
+		return fmt.Errorf("subscription %s: %w", id, entity.ErrOutsideTerm)
+	}
+
+	return fmt.Errorf("subscription %s: %w", id, entity.ErrNotFound)
+}
+
+// GetPriceChanges возвращает изменения цены подписки в порядке вступления в силу
+func (r *subRepository) GetPriceChanges(ctx context.Context, id string) ([]entity.PriceChange, error) {
+	rows, err := r.db.Query(
+		ctx,
+		`SELECT subscription_id, effective_from, price, created_at, created_by
+		FROM subscription_price_changes
+		WHERE subscription_id = $1
+		ORDER BY effective_from`,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load price changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []entity.PriceChange{}
+	for rows.Next() {
+		var c entity.PriceChange
+		if err := rows.Scan(&c.SubscriptionId, &c.EffectiveFrom, &c.Price, &c.CreatedAt, &c.CreatedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan price change: %w", err)
+		}
+
+		changes = append(changes, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load price changes: %w", err)
+	}
+
+	return changes, nil
+}
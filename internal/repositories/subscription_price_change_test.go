@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"subscriptions/pkg/auth"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSubRepository_AddPriceChange_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := auth.WithPrincipal(context.Background(), "alice")
+	change := &entity.PriceChange{SubscriptionId: "sub-123", EffectiveFrom: yearMonth("04-2025"), Price: 39900}
+
+	mockDB.EXPECT().
+		QueryRow(
+			ctx,
+			gomock.Cond(func(sql any) bool {
+				return assert.Contains(t, sql, "INSERT INTO subscription_price_changes") &&
+					assert.Contains(t, sql, "WHERE id = $1 AND deleted_at IS NULL") &&
+					assert.Contains(t, sql, "start_date < $2 AND (end_date IS NULL OR end_date >= $2)") &&
+					assert.Contains(t, sql, "FOR SHARE")
+			}),
+			"sub-123", yearMonth("04-2025"), entity.Amount(39900), gomock.Cond(func(p any) bool { return *p.(*string) == "alice" }),
+		).
+		Return(mockRow)
+
+	mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*string)) = "sub-123"
+			*(dest[1].(*entity.YearMonth)) = yearMonth("04-2025")
+			*(dest[2].(*entity.Amount)) = 39900
+			createdBy := "alice"
+			*(dest[4].(**string)) = &createdBy
+			return nil
+		})
+
+	out, err := repo.AddPriceChange(ctx, change)
+
+	require.NoError(t, err)
+	assert.Equal(t, "04-2025", out.EffectiveFrom.String())
+	assert.Equal(t, entity.Amount(39900), out.Price)
+	assert.Equal(t, "alice", *out.CreatedBy)
+}
+
+func TestSubRepository_AddPriceChange_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		exists  bool
+		wantErr error
+	}{
+		{"subscription missing", false, entity.ErrNotFound},
+		{"outside term", true, entity.ErrOutsideTerm},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDB(ctrl)
+			insertRow := mocks.NewMockRow(ctrl)
+			existsRow := mocks.NewMockRow(ctrl)
+			repo := &subRepository{db: mockDB}
+
+			ctx := context.Background()
+
+			mockDB.EXPECT().
+				QueryRow(ctx, gomock.Any(), "sub-123", yearMonth("04-2025"), entity.Amount(100), gomock.Nil()).
+				Return(insertRow)
+			insertRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows)
+
+			mockDB.EXPECT().
+				QueryRow(ctx, gomock.Cond(func(sql any) bool {
+					return assert.Contains(t, sql, "SELECT EXISTS")
+				}), "sub-123").
+				Return(existsRow)
+			existsRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...interface{}) error {
+				*(dest[0].(*bool)) = tt.exists
+				return nil
+			})
+
+			_, err := repo.AddPriceChange(ctx, &entity.PriceChange{SubscriptionId: "sub-123", EffectiveFrom: yearMonth("04-2025"), Price: 100})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSubRepository_GetPriceChanges_Empty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRows := mocks.NewMockRows(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+
+	mockDB.EXPECT().
+		Query(ctx, gomock.Any(), "sub-123").
+		Return(mockRows, nil)
+
+	mockRows.EXPECT().Next().Return(false)
+	mockRows.EXPECT().Err().Return(nil)
+	mockRows.EXPECT().Close()
+
+	changes, err := repo.GetPriceChanges(ctx, "sub-123")
+
+	require.NoError(t, err)
+	assert.NotNil(t, changes)
+	assert.Empty(t, changes)
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PurgeDeleted окончательно удаляет до limit подписок, мягко удаленных раньше before, и возвращает их число.
+// Очистка идет пачками, чтобы не держать блокировки на большом числе строк в одной транзакции
+func (r *subRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int64, error) {
+
+	tag, err := r.db.Exec(
+		ctx,
+		`DELETE FROM subscriptions
+		WHERE id IN (
+			SELECT id FROM subscriptions
+			WHERE deleted_at < $1
+			LIMIT $2
+		)`,
+		before,
+		limit,
+	)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to PURGE subscriptions: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
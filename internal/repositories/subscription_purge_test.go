@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSubRepository_PurgeDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	before := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	mockDB.EXPECT().
+		Exec(
+			ctx,
+			gomock.Cond(func(sql any) bool {
+				return assert.Contains(t, sql, "DELETE FROM subscriptions") &&
+					assert.Contains(t, sql, "WHERE deleted_at < $1") &&
+					assert.Contains(t, sql, "LIMIT $2")
+			}),
+			before, 500,
+		).
+		Return(pgconn.NewCommandTag("DELETE 42"), nil)
+
+	purged, err := repo.PurgeDeleted(ctx, before, 500)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), purged)
+}
+
+func TestSubRepository_PurgeDeleted_DBError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+
+	mockDB.EXPECT().
+		Exec(ctx, gomock.Any(), gomock.Any(), 500).
+		Return(pgconn.NewCommandTag(""), assert.AnError)
+
+	_, err := repo.PurgeDeleted(ctx, time.Now(), 500)
+
+	assert.ErrorIs(t, err, assert.AnError)
+}
@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"subscriptions/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Restore снимает мягкое удаление и возвращает подписку. Восстановление идемпотентно: действующая подписка
+// возвращается без изменений. Ненулевая version восстанавливает подписку, только если она не менялась с этой версии
+func (r *subRepository) Restore(ctx context.Context, id string, version int64) (*entity.Subscription, error) {
+
+	var sub entity.Subscription
+
+	row := r.db.QueryRow(
+		ctx,
+		`UPDATE subscriptions
+		SET deleted_at = NULL, version = version + 1, updated_at = now(), updated_by = $3
+		WHERE id = $1 AND deleted_at IS NOT NULL AND ($2::bigint = 0 OR version = $2)
+		RETURNING `+subscriptionColumns,
+		id,
+		version,
+		principal(ctx),
+	)
+
+	err := scanSubscription(row, &sub)
+	if err == nil {
+		return &sub, nil
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to RESTORE subscription: %w", err)
+	}
+
+	// Удаленной подписки с такой версией нет: возможно, ее уже восстановили
+	current, err := r.GetById(ctx, id)
+	if err == nil {
+		if version != 0 && current.Version != version {
+			return nil, fmt.Errorf("subscription %s: %w", id, entity.ErrVersionMismatch)
+		}
+		return current, nil
+	}
+
+	if !errors.Is(err, entity.ErrNotFound) {
+		return nil, err
+	}
+
+	return nil, r.missingError(ctx, id, version, true)
+}
@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"context"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSubRepository_Restore_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+
+	mockDB.EXPECT().
+		QueryRow(
+			ctx,
+			gomock.Cond(func(sql any) bool {
+				return assert.Contains(t, sql, "SET deleted_at = NULL") &&
+					assert.Contains(t, sql, "WHERE id = $1 AND deleted_at IS NOT NULL")
+			}),
+			"sub-123", int64(0), gomock.Nil(),
+		).
+		Return(mockRow)
+
+	mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*string)) = "sub-123"
+			*(dest[9].(*int64)) = 3
+			return nil
+		})
+
+	sub, err := repo.Restore(ctx, "sub-123", 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), sub.Version)
+	assert.Nil(t, sub.DeletedAt)
+}
+
+func TestSubRepository_Restore_AlreadyActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRestoreRow := mocks.NewMockRow(ctrl)
+	mockGetRow := mocks.NewMockRow(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+
+	gomock.InOrder(
+		mockDB.EXPECT().QueryRow(ctx, gomock.Any(), "sub-123", int64(0), gomock.Nil()).Return(mockRestoreRow),
+		mockDB.EXPECT().QueryRow(ctx, gomock.Any(), "sub-123").Return(mockGetRow),
+	)
+
+	mockRestoreRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows)
+
+	// Подписка не удалена: восстановление идемпотентно и возвращает ее как есть
+	mockGetRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*string)) = "sub-123"
+			*(dest[9].(*int64)) = 2
+			return nil
+		})
+
+	sub, err := repo.Restore(ctx, "sub-123", 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, "sub-123", sub.Id)
+	assert.Equal(t, int64(2), sub.Version)
+}
+
+func TestSubRepository_Restore_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRestoreRow := mocks.NewMockRow(ctrl)
+	mockGetRow := mocks.NewMockRow(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+
+	gomock.InOrder(
+		mockDB.EXPECT().QueryRow(ctx, gomock.Any(), "sub-123", int64(0), gomock.Nil()).Return(mockRestoreRow),
+		mockDB.EXPECT().QueryRow(ctx, gomock.Any(), "sub-123").Return(mockGetRow),
+	)
+
+	mockRestoreRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows)
+	mockGetRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows)
+
+	sub, err := repo.Restore(ctx, "sub-123", 0)
+
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+	assert.Nil(t, sub)
+}
+
+func TestSubRepository_Restore_DeletedVersionMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	mockRestoreRow := mocks.NewMockRow(ctrl)
+	mockGetRow := mocks.NewMockRow(ctrl)
+	mockExistsRow := mocks.NewMockRow(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+
+	gomock.InOrder(
+		mockDB.EXPECT().QueryRow(ctx, gomock.Any(), "sub-123", int64(5), gomock.Nil()).Return(mockRestoreRow),
+		mockDB.EXPECT().QueryRow(ctx, gomock.Any(), "sub-123").Return(mockGetRow),
+		mockDB.EXPECT().QueryRow(ctx, gomock.Any(), "sub-123", true).Return(mockExistsRow),
+	)
+
+	mockRestoreRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows)
+	mockGetRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows)
+
+	// Удаленная подписка есть, значит ее версия уже не 5
+	mockExistsRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*bool)) = true
+			return nil
+		})
+
+	sub, err := repo.Restore(ctx, "sub-123", 5)
+
+	assert.ErrorIs(t, err, entity.ErrVersionMismatch)
+	assert.Nil(t, sub)
+}
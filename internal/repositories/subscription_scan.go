@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"subscriptions/internal/entity"
+	"subscriptions/pkg/auth"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// subscriptionColumns — колонки подписки в порядке, в котором их читает scanSubscription
+const subscriptionColumns = `id, service_name, price, user_id, start_date, end_date, billing_period, billing_interval, currency, version,
+		created_at, updated_at, created_by, updated_by, deleted_at`
+
+// scanSubscription читает строку, выбранную по subscriptionColumns
+func scanSubscription(row pgx.Row, sub *entity.Subscription) error {
+	return row.Scan(&sub.Id, &sub.Name, &sub.Price.Amount, &sub.UserId, &sub.StartDate, &sub.EndDate,
+		&sub.BillingPeriod, &sub.BillingInterval, &sub.Price.Currency, &sub.Version,
+		&sub.CreatedAt, &sub.UpdatedAt, &sub.CreatedBy, &sub.UpdatedBy, &sub.DeletedAt)
+}
+
+// principal возвращает автора изменения из контекста запроса. У анонимного запроса автора нет, в базу пишется NULL
+func principal(ctx context.Context) *string {
+	if p := auth.PrincipalFromCtx(ctx); p != "" {
+		return &p
+	}
+	return nil
+}
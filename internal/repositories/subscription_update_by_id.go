@@ -2,49 +2,46 @@ package repositories
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
 	"subscriptions/internal/entity"
-)
 
-func (r *subRepository) Update(ctx context.Context, subIn *entity.Subscription) error {
+	"github.com/jackc/pgx/v5"
+)
 
-	startDateForDB, err := parseDateToDB(subIn.StartDate)
-	if err != nil {
-		return fmt.Errorf("invalid start date: %v", err)
-	}
+// Update перезаписывает все поля подписки и возвращает ее после изменения.
+// Отсутствие подписки и несовпадение версии определяются по пустому RETURNING в том же запросе
+func (r *subRepository) Update(ctx context.Context, subIn *entity.Subscription) (*entity.Subscription, error) {
 
-	var endDateForDB interface{}
-	if subIn.EndDate == "" {
-		endDateForDB = nil
-	} else {
-		parsedEndDate, err := parseDateToDB(subIn.EndDate)
-		if err != nil {
-			return fmt.Errorf("invalid end date: %v", err)
-		}
-		endDateForDB = parsedEndDate
-	}
+	var sub entity.Subscription
 
-	_, err = r.db.Exec(
+	row := r.db.QueryRow(
 		ctx,
 		`Update subscriptions 
-		SET service_name = $2, price = $3, user_id = $4, start_date = $5, end_date = $6
-		WHERE id = $1`,
+		SET service_name = $2, price = $3, user_id = $4, start_date = $5, end_date = $6,
+		billing_period = $7, billing_interval = $8, currency = $9,
+		version = version + 1, updated_at = now(), updated_by = $11
+		WHERE id = $1 AND deleted_at IS NULL AND ($10::bigint = 0 OR version = $10)
+		RETURNING `+subscriptionColumns,
 		subIn.Id,
 		subIn.Name,
-		subIn.Price,
+		subIn.Price.Amount,
 		subIn.UserId,
-		startDateForDB,
-		endDateForDB,
+		subIn.StartDate,
+		subIn.EndDate,
+		subIn.BillingPeriod,
+		subIn.BillingInterval,
+		subIn.Price.Currency,
+		subIn.Version,
+		principal(ctx),
 	)
 
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return err
+	if err := scanSubscription(row, &sub); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, r.missingError(ctx, subIn.Id, subIn.Version, false)
 		}
-		return fmt.Errorf("failed to UPDATE subscription: %v", err)
+		return nil, fmt.Errorf("failed to UPDATE subscription: %w", err)
 	}
 
-	return nil
+	return &sub, nil
 }
@@ -2,13 +2,12 @@ package repositories
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"subscriptions/internal/entity"
 	"subscriptions/internal/repositories/mocks"
 	"testing"
 
-	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -18,32 +17,42 @@ func TestSubRepository_UpdateById_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
 	repo := &subRepository{db: mockDB}
 
 	ctx := context.Background()
 	sub := &entity.Subscription{
-		Id:        "sub-123",
-		Name:      "Yandex Plus Premium",
-		Price:     2000,
-		UserId:    "user-123",
-		StartDate: "01-2025",
-		EndDate:   "12-2025",
+		Id:              "sub-123",
+		Name:            "Yandex Plus Premium",
+		Price:           entity.Money{Amount: 2000, Currency: "RUB"},
+		UserId:          "user-123",
+		StartDate:       yearMonth("01-2025"),
+		EndDate:         yearMonthPtr("12-2025"),
+		BillingPeriod:   entity.BillingPeriodYear,
+		BillingInterval: 1,
 	}
 
-	expectedStartDateStr := "2025-01-01"
-	expectedEndDateStr := "2025-12-01"
-
 	mockDB.EXPECT().
-		Exec(
+		QueryRow(
 			ctx,
-			gomock.Any(), 
-			"sub-123", "Yandex Plus Premium", 2000, "user-123", expectedStartDateStr, expectedEndDateStr,
+			gomock.Any(),
+			"sub-123", "Yandex Plus Premium", entity.Amount(2000), "user-123", sub.StartDate, sub.EndDate, entity.BillingPeriodYear, 1, "RUB", int64(0), gomock.Nil(),
 		).
-		Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+		Return(mockRow)
 
-	err := repo.Update(ctx, sub)
+	mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*string)) = sub.Id
+			*(dest[1].(*string)) = sub.Name
+			return nil
+		})
+
+	updated, err := repo.Update(ctx, sub)
 
 	assert.NoError(t, err)
+	assert.Equal(t, sub.Id, updated.Id)
+	assert.Equal(t, sub.Name, updated.Name)
 }
 
 func TestSubRepository_UpdateById_WithoutEndDate(t *testing.T) {
@@ -51,31 +60,41 @@ func TestSubRepository_UpdateById_WithoutEndDate(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
 	repo := &subRepository{db: mockDB}
 
 	ctx := context.Background()
 	sub := &entity.Subscription{
-		Id:        "sub-123",
-		Name:      "Yandex Plus",
-		Price:     1500,
-		UserId:    "user-123",
-		StartDate: "01-2025",
-		EndDate:   "", 
+		Id:              "sub-123",
+		Name:            "Yandex Plus",
+		Price:           entity.Money{Amount: 1500, Currency: "RUB"},
+		UserId:          "user-123",
+		StartDate:       yearMonth("01-2025"),
+		BillingPeriod:   entity.BillingPeriodMonth,
+		BillingInterval: 1,
 	}
 
-	expectedStartDateStr := "2025-01-01"
-
 	mockDB.EXPECT().
-		Exec(
+		QueryRow(
 			ctx,
 			gomock.Any(),
-			"sub-123", "Yandex Plus", 1500, "user-123", expectedStartDateStr, nil,
+			"sub-123", "Yandex Plus", entity.Amount(1500), "user-123", sub.StartDate, nil, entity.BillingPeriodMonth, 1, "RUB", int64(0), gomock.Nil(),
 		).
-		Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+		Return(mockRow)
 
-	err := repo.Update(ctx, sub)
+	mockRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*string)) = sub.Id
+			*(dest[1].(*string)) = sub.Name
+			return nil
+		})
+
+	updated, err := repo.Update(ctx, sub)
 
 	assert.NoError(t, err)
+	assert.Equal(t, sub.Id, updated.Id)
+	assert.Equal(t, sub.Name, updated.Name)
 }
 
 func TestSubRepository_UpdateById_NotFound(t *testing.T) {
@@ -83,33 +102,36 @@ func TestSubRepository_UpdateById_NotFound(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
 	repo := &subRepository{db: mockDB}
 
 	ctx := context.Background()
 	sub := &entity.Subscription{
-		Id:        "non-existent-id",
-		Name:      "Yandex Plus",
-		Price:     1500,
-		UserId:    "user-123",
-		StartDate: "01-2025",
-		EndDate:   "12-2025",
+		Id:              "non-existent-id",
+		Name:            "Yandex Plus",
+		Price:           entity.Money{Amount: 1500, Currency: "RUB"},
+		UserId:          "user-123",
+		StartDate:       yearMonth("01-2025"),
+		EndDate:         yearMonthPtr("12-2025"),
+		BillingPeriod:   entity.BillingPeriodMonth,
+		BillingInterval: 1,
 	}
 
-	expectedStartDateStr := "2025-01-01"
-	expectedEndDateStr := "2025-12-01"
-
 	mockDB.EXPECT().
-		Exec(
+		QueryRow(
 			ctx,
 			gomock.Any(),
-			"non-existent-id", "Yandex Plus", 1500, "user-123", expectedStartDateStr, expectedEndDateStr,
+			"non-existent-id", "Yandex Plus", entity.Amount(1500), "user-123", sub.StartDate, sub.EndDate, entity.BillingPeriodMonth, 1, "RUB", int64(0), gomock.Nil(),
 		).
-		Return(pgconn.NewCommandTag("UPDATE 0"), sql.ErrNoRows)
+		Return(mockRow)
 
-	err := repo.Update(ctx, sub)
+	// Пустой RETURNING означает, что подписки с таким id нет
+	mockRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows)
 
-	assert.Error(t, err)
-	assert.True(t, errors.Is(err, sql.ErrNoRows))
+	updated, err := repo.Update(ctx, sub)
+
+	assert.True(t, errors.Is(err, entity.ErrNotFound))
+	assert.Nil(t, updated)
 }
 
 func TestSubRepository_UpdateById_DBError(t *testing.T) {
@@ -117,77 +139,84 @@ func TestSubRepository_UpdateById_DBError(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
 	repo := &subRepository{db: mockDB}
 
 	ctx := context.Background()
 	sub := &entity.Subscription{
-		Id:        "sub-123",
-		Name:      "Yandex Plus",
-		Price:     1500,
-		UserId:    "user-123",
-		StartDate: "01-2025",
-		EndDate:   "12-2025",
+		Id:              "sub-123",
+		Name:            "Yandex Plus",
+		Price:           entity.Money{Amount: 1500, Currency: "RUB"},
+		UserId:          "user-123",
+		StartDate:       yearMonth("01-2025"),
+		EndDate:         yearMonthPtr("12-2025"),
+		BillingPeriod:   entity.BillingPeriodMonth,
+		BillingInterval: 1,
 	}
 
-	expectedStartDateStr := "2025-01-01"
-	expectedEndDateStr := "2025-12-01"
-
 	mockDB.EXPECT().
-		Exec(
+		QueryRow(
 			ctx,
 			gomock.Any(),
-			"sub-123", "Yandex Plus", 1500, "user-123", expectedStartDateStr, expectedEndDateStr,
+			"sub-123", "Yandex Plus", entity.Amount(1500), "user-123", sub.StartDate, sub.EndDate, entity.BillingPeriodMonth, 1, "RUB", int64(0), gomock.Nil(),
 		).
-		Return(pgconn.NewCommandTag(""), assert.AnError)
+		Return(mockRow)
+
+	mockRow.EXPECT().Scan(gomock.Any()).Return(assert.AnError)
 
-	err := repo.Update(ctx, sub)
+	_, err := repo.Update(ctx, sub)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to UPDATE subscription")
 }
 
-func TestSubRepository_Update_InvalidStartDate(t *testing.T) {
+func TestSubRepository_UpdateById_VersionMismatch(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockDB := mocks.NewMockDB(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
+	mockExistsRow := mocks.NewMockRow(ctrl)
 	repo := &subRepository{db: mockDB}
 
 	ctx := context.Background()
 	sub := &entity.Subscription{
-		Id:        "sub-123",
-		Name:      "Yandex Plus",
-		Price:     1500,
-		UserId:    "user-123",
-		StartDate: "invalid-date", // Невалидная дата
-		EndDate:   "12-2025",
+		Id:              "sub-123",
+		Name:            "Yandex Plus",
+		Price:           entity.Money{Amount: 1500, Currency: "RUB"},
+		UserId:          "user-123",
+		StartDate:       yearMonth("01-2025"),
+		BillingPeriod:   entity.BillingPeriodMonth,
+		BillingInterval: 1,
+		Version:         4,
 	}
 
-	err := repo.Update(ctx, sub)
-
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "invalid start date")
-}
+	mockDB.EXPECT().
+		QueryRow(
+			ctx,
+			gomock.Cond(func(sql any) bool {
+				return assert.Contains(t, sql, "version = version + 1") &&
+					assert.Contains(t, sql, "WHERE id = $1 AND deleted_at IS NULL AND ($10::bigint = 0 OR version = $10)")
+			}),
+			"sub-123", "Yandex Plus", entity.Amount(1500), "user-123", sub.StartDate, nil, entity.BillingPeriodMonth, 1, "RUB", int64(4), gomock.Nil(),
+		).
+		Return(mockRow)
 
-func TestSubRepository_UpdateById_InvalidEndDate(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+	mockRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows)
 
-	mockDB := mocks.NewMockDB(ctrl)
-	repo := &subRepository{db: mockDB}
+	mockDB.EXPECT().
+		QueryRow(ctx, gomock.Any(), "sub-123", false).
+		Return(mockExistsRow)
 
-	ctx := context.Background()
-	sub := &entity.Subscription{
-		Id:        "sub-123",
-		Name:      "Yandex Plus",
-		Price:     1500,
-		UserId:    "user-123",
-		StartDate: "01-2025",
-		EndDate:   "invalid-date", // Невалидная дата
-	}
+	mockExistsRow.EXPECT().
+		Scan(gomock.Any()).
+		DoAndReturn(func(dest ...interface{}) error {
+			*(dest[0].(*bool)) = true
+			return nil
+		})
 
-	err := repo.Update(ctx, sub)
+	updated, err := repo.Update(ctx, sub)
 
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "invalid end date")
+	assert.ErrorIs(t, err, entity.ErrVersionMismatch)
+	assert.Nil(t, updated)
 }
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"subscriptions/internal/entity"
+)
+
+// missingError объясняет, почему условная запись не затронула ни одной строки: подписки нет или ее версия
+// отличается от ожидаемой. Проверка выполняется уже после неудачной записи, сама запись остается одним запросом.
+// deleted указывает, среди каких подписок искать: запись меняет действующие, восстановление — удаленные
+func (r *subRepository) missingError(ctx context.Context, id string, version int64, deleted bool) error {
+	if version == 0 {
+		return fmt.Errorf("subscription %s: %w", id, entity.ErrNotFound)
+	}
+
+	var exists bool
+	err := r.db.QueryRow(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM subscriptions WHERE id = $1 AND (deleted_at IS NOT NULL) = $2)`,
+		id,
+		deleted,
+	).Scan(&exists)
+
+	if err != nil {
+		return fmt.Errorf("failed to check subscription version: %w", err)
+	}
+
+	if exists {
+		return fmt.Errorf("subscription %s: %w", id, entity.ErrVersionMismatch)
+	}
+
+	return fmt.Errorf("subscription %s: %w", id, entity.ErrNotFound)
+}
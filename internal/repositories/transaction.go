@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"subscriptions/internal/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Коды ошибок PostgreSQL, после которых транзакцию можно безопасно повторить целиком
+const (
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+const (
+	maxTxAttempts = 3
+	txRetryDelay  = 10 * time.Millisecond
+)
+
+type txKey struct{}
+
+// txIsoLevels переводит уровни изоляции в pgx. Пустой уровень остается пустым — это уровень по умолчанию базы
+var txIsoLevels = map[entity.IsolationLevel]pgx.TxIsoLevel{
+	entity.ReadCommitted:  pgx.ReadCommitted,
+	entity.RepeatableRead: pgx.RepeatableRead,
+	entity.Serializable:   pgx.Serializable,
+}
+
+// querier — запросы, общие у пула и транзакции
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+// conn возвращает транзакцию, начатую WithTx выше по контексту, или пул
+func (a *PgxPoolAdapter) conn(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return a.pool
+}
+
+// WithTx выполняет fn в транзакции и повторяет ее при конфликте сериализации или взаимной блокировке,
+// поэтому fn может вызываться несколько раз и не должна иметь побочных эффектов вне базы.
+// Вложенный вызов продолжает внешнюю транзакцию: повторить ее целиком может только внешний WithTx
+func (a *PgxPoolAdapter) WithTx(ctx context.Context, opts entity.TxOptions, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	isoLevel, ok := txIsoLevels[opts.Isolation]
+	if !ok && opts.Isolation != "" {
+		return fmt.Errorf("unsupported isolation level %q", opts.Isolation)
+	}
+
+	txOptions := pgx.TxOptions{IsoLevel: isoLevel}
+	if opts.ReadOnly {
+		txOptions.AccessMode = pgx.ReadOnly
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = pgx.BeginTxFunc(ctx, a.pool, txOptions, func(tx pgx.Tx) error {
+			return fn(context.WithValue(ctx, txKey{}, tx))
+		})
+
+		if attempt == maxTxAttempts || !isRetryable(err) {
+			return err
+		}
+
+		// Случайная добавка к паузе разводит повторы конкурирующих транзакций во времени
+		delay := txRetryDelay << (attempt - 1)
+		delay += rand.N(delay)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && (pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected)
+}
+
+func (r *subRepository) WithTx(ctx context.Context, opts entity.TxOptions, fn func(ctx context.Context) error) error {
+	return r.db.WithTx(ctx, opts, fn)
+}
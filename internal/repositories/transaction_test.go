@@ -0,0 +1,259 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, true},
+		{"deadlock", &pgconn.PgError{Code: "40P01"}, true},
+		{"wrapped serialization failure", fmt.Errorf("failed to UPDATE subscription: %w", &pgconn.PgError{Code: "40001"}), true},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false},
+		{"not found", entity.ErrNotFound, false},
+		{"other error", errors.New("connection reset"), false},
+		{"no error", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRetryable(tt.err))
+		})
+	}
+}
+
+func TestSubRepository_WithTx_DelegatesToDB(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDB(ctrl)
+	repo := &subRepository{db: mockDB}
+
+	ctx := context.Background()
+	opts := entity.TxOptions{Isolation: entity.Serializable}
+	fnErr := errors.New("rollback")
+
+	mockDB.EXPECT().
+		WithTx(ctx, opts, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ entity.TxOptions, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	err := repo.WithTx(ctx, opts, func(ctx context.Context) error { return fnErr })
+
+	assert.ErrorIs(t, err, fnErr)
+}
+
+// newMockTx возвращает транзакцию, которую pgx.BeginTxFunc может откатывать сколько угодно раз:
+// после Commit откат возвращает ErrTxClosed и игнорируется
+func newMockTx(ctrl *gomock.Controller) *mocks.MockTx {
+	tx := mocks.NewMockTx(ctrl)
+	tx.EXPECT().Rollback(gomock.Any()).Return(pgx.ErrTxClosed).AnyTimes()
+	return tx
+}
+
+func TestPgxPoolAdapter_WithTx_QueriesRunInTx(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mocks.NewMockPool(ctrl)
+	mockTx := newMockTx(ctrl)
+	mockRow := mocks.NewMockRow(ctrl)
+	adapter := &PgxPoolAdapter{pool: mockPool}
+
+	ctx := context.Background()
+
+	mockPool.EXPECT().BeginTx(ctx, gomock.Any()).Return(mockTx, nil)
+	// Запрос с контекстом транзакции идет в транзакцию, а не в пул
+	mockTx.EXPECT().QueryRow(gomock.Any(), "SELECT 1").Return(mockRow)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	err := adapter.WithTx(ctx, entity.TxOptions{}, func(ctx context.Context) error {
+		adapter.QueryRow(ctx, "SELECT 1")
+		return nil
+	})
+
+	require.NoError(t, err)
+
+	// Вне WithTx запрос снова идет в пул
+	mockPool.EXPECT().QueryRow(ctx, "SELECT 2").Return(mockRow)
+	adapter.QueryRow(ctx, "SELECT 2")
+}
+
+func TestPgxPoolAdapter_WithTx_IsolationLevels(t *testing.T) {
+	tests := []struct {
+		name string
+		opts entity.TxOptions
+		want pgx.TxOptions
+	}{
+		{"default", entity.TxOptions{}, pgx.TxOptions{}},
+		{"read committed", entity.TxOptions{Isolation: entity.ReadCommitted}, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}},
+		{"repeatable read", entity.TxOptions{Isolation: entity.RepeatableRead}, pgx.TxOptions{IsoLevel: pgx.RepeatableRead}},
+		{"serializable", entity.TxOptions{Isolation: entity.Serializable}, pgx.TxOptions{IsoLevel: pgx.Serializable}},
+		{"read only", entity.TxOptions{Isolation: entity.RepeatableRead, ReadOnly: true},
+			pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := mocks.NewMockPool(ctrl)
+			mockTx := newMockTx(ctrl)
+			adapter := &PgxPoolAdapter{pool: mockPool}
+
+			mockPool.EXPECT().BeginTx(gomock.Any(), tt.want).Return(mockTx, nil)
+			mockTx.EXPECT().Commit(gomock.Any()).Return(nil)
+
+			err := adapter.WithTx(context.Background(), tt.opts, func(ctx context.Context) error { return nil })
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPgxPoolAdapter_WithTx_UnsupportedIsolationLevel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Транзакция с неизвестным уровнем не начинается, а не выполняется молча на уровне по умолчанию
+	adapter := &PgxPoolAdapter{pool: mocks.NewMockPool(ctrl)}
+
+	err := adapter.WithTx(context.Background(), entity.TxOptions{Isolation: "snapshot"}, func(ctx context.Context) error {
+		t.Fatal("fn must not run")
+		return nil
+	})
+
+	assert.ErrorContains(t, err, "unsupported isolation level")
+}
+
+func TestPgxPoolAdapter_WithTx_RetriesSerializationFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mocks.NewMockPool(ctrl)
+	mockTx := newMockTx(ctrl)
+	adapter := &PgxPoolAdapter{pool: mockPool}
+
+	mockPool.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil).Times(maxTxAttempts)
+	mockTx.EXPECT().Commit(gomock.Any()).Return(nil).Times(1)
+
+	// Две первые попытки прерываются конфликтом сериализации, третья фиксируется
+	calls := 0
+	started := time.Now()
+	err := adapter.WithTx(context.Background(), entity.TxOptions{Isolation: entity.Serializable}, func(ctx context.Context) error {
+		calls++
+		if calls < maxTxAttempts {
+			return fmt.Errorf("failed to UPDATE subscription: %w", &pgconn.PgError{Code: pgSerializationFailure})
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, maxTxAttempts, calls)
+	// Паузы перед повторами растут: не меньше txRetryDelay и 2*txRetryDelay
+	assert.GreaterOrEqual(t, time.Since(started), 3*txRetryDelay)
+}
+
+func TestPgxPoolAdapter_WithTx_GivesUpAfterMaxAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mocks.NewMockPool(ctrl)
+	mockTx := newMockTx(ctrl)
+	adapter := &PgxPoolAdapter{pool: mockPool}
+
+	mockPool.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil).Times(maxTxAttempts)
+
+	calls := 0
+	err := adapter.WithTx(context.Background(), entity.TxOptions{}, func(ctx context.Context) error {
+		calls++
+		return &pgconn.PgError{Code: pgDeadlockDetected}
+	})
+
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	assert.Equal(t, pgDeadlockDetected, pgErr.Code)
+	assert.Equal(t, maxTxAttempts, calls)
+}
+
+func TestPgxPoolAdapter_WithTx_DoesNotRetryOtherErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mocks.NewMockPool(ctrl)
+	mockTx := newMockTx(ctrl)
+	adapter := &PgxPoolAdapter{pool: mockPool}
+
+	mockPool.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil).Times(1)
+
+	uniqueViolation := &pgconn.PgError{Code: "23505"}
+	err := adapter.WithTx(context.Background(), entity.TxOptions{}, func(ctx context.Context) error {
+		return uniqueViolation
+	})
+
+	assert.ErrorIs(t, err, uniqueViolation)
+}
+
+func TestPgxPoolAdapter_WithTx_StopsRetryingOnCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mocks.NewMockPool(ctrl)
+	mockTx := newMockTx(ctrl)
+	adapter := &PgxPoolAdapter{pool: mockPool}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockPool.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil).Times(1)
+
+	// Контекст отменяется до паузы перед повтором: WithTx возвращает ошибку попытки и больше не пробует
+	err := adapter.WithTx(ctx, entity.TxOptions{}, func(ctx context.Context) error {
+		cancel()
+		return &pgconn.PgError{Code: pgSerializationFailure}
+	})
+
+	assert.True(t, isRetryable(err))
+}
+
+func TestPgxPoolAdapter_WithTx_NestedJoinsOuterTx(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mocks.NewMockPool(ctrl)
+	mockTx := newMockTx(ctrl)
+	adapter := &PgxPoolAdapter{pool: mockPool}
+
+	// Вложенный WithTx не начинает вторую транзакцию
+	mockPool.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil).Times(1)
+	mockTx.EXPECT().Exec(gomock.Any(), "UPDATE 1").Return(pgconn.CommandTag{}, nil)
+	mockTx.EXPECT().Commit(gomock.Any()).Return(nil).Times(1)
+
+	err := adapter.WithTx(context.Background(), entity.TxOptions{}, func(outer context.Context) error {
+		return adapter.WithTx(outer, entity.TxOptions{Isolation: entity.Serializable}, func(inner context.Context) error {
+			assert.Equal(t, outer, inner)
+			_, err := adapter.Exec(inner, "UPDATE 1")
+			return err
+		})
+	})
+
+	require.NoError(t, err)
+}
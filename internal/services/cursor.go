@@ -0,0 +1,98 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"subscriptions/internal/entity"
+	"time"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// Подписки без даты окончания при сортировке по end_date считаются бессрочными и идут после остальных
+const openEndDate = "infinity"
+
+// historySortKey — ключ курсора журнала изменений, упорядоченного по id события
+const historySortKey = "event_id"
+
+// encodeCursor упаковывает курсор в непрозрачную для клиента строку
+func encodeCursor(c entity.Cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor разбирает курсор и проверяет, что он построен для той же сортировки
+func decodeCursor(s string, sort entity.Sort) (*entity.Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	var c entity.Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, errInvalidCursor
+	}
+
+	if c.SortKey != sort.Key || c.Order != sort.Order || c.LastId == "" || !isValidSortValue(c.SortKey, c.LastValue) {
+		return nil, errInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// nextCursor строит курсор, указывающий на позицию сразу после sub
+func nextCursor(sub entity.Subscription, sort entity.Sort) string {
+	return encodeCursor(entity.Cursor{
+		SortKey:   sort.Key,
+		Order:     sort.Order,
+		LastValue: sortValue(sub, sort.Key),
+		LastId:    sub.Id,
+	})
+}
+
+// sortValue возвращает значение ключа сортировки в текстовом виде, который понимает PostgreSQL
+func sortValue(sub entity.Subscription, key string) string {
+	switch key {
+	case entity.SortKeyPrice:
+		return strconv.FormatInt(int64(sub.Price.Amount), 10)
+	case entity.SortKeyStartDate:
+		return sub.StartDate.Time().Format(time.DateOnly)
+	case entity.SortKeyEndDate:
+		if sub.EndDate == nil {
+			return openEndDate
+		}
+		return sub.EndDate.Time().Format(time.DateOnly)
+	case entity.SortKeyServiceName:
+		return sub.Name
+	case entity.SortKeyUpdatedAt:
+		return sub.UpdatedAt.Format(time.RFC3339Nano)
+	default:
+		return ""
+	}
+}
+
+// isValidSortValue не дает подделанному курсору дойти до базы с неприводимым к типу колонки значением
+func isValidSortValue(key, value string) bool {
+	switch key {
+	case entity.SortKeyId, historySortKey:
+		return value == ""
+	case entity.SortKeyPrice:
+		_, err := strconv.ParseInt(value, 10, 64)
+		return err == nil
+	case entity.SortKeyStartDate:
+		_, err := time.Parse(time.DateOnly, value)
+		return err == nil
+	case entity.SortKeyEndDate:
+		_, err := time.Parse(time.DateOnly, value)
+		return err == nil || value == openEndDate
+	case entity.SortKeyServiceName:
+		return true
+	case entity.SortKeyUpdatedAt:
+		_, err := time.Parse(time.RFC3339Nano, value)
+		return err == nil
+	default:
+		return false
+	}
+}
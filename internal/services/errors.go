@@ -0,0 +1,80 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"subscriptions/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	// ErrValidation — входные данные не прошли проверку, подробности по полям в ValidationError
+	ErrValidation = errors.New("validation failed")
+	// ErrNotFound — запрошенный объект не существует. Общий с репозиторием, чтобы не переводить одну ошибку в другую
+	ErrNotFound = entity.ErrNotFound
+	// ErrConflict — операция противоречит текущему состоянию данных
+	ErrConflict = errors.New("conflict")
+	// ErrPreconditionFailed — подписку изменили после того, как клиент получил ее версию
+	ErrPreconditionFailed = entity.ErrVersionMismatch
+	// ErrPriceRewrite — изменение переписало бы цену уже прошедших месяцев или сохраненных изменений цены
+	ErrPriceRewrite = errors.New("change would rewrite price history")
+)
+
+// FieldError описывает ошибку в одном поле запроса
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError содержит все найденные ошибки по полям, errors.Is(err, ErrValidation) для нее истинно
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Field+": "+f.Message)
+	}
+
+	return fmt.Sprintf("%s: %s", ErrValidation, strings.Join(msgs, "; "))
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Коды ошибок PostgreSQL, которые означают конфликт с уже сохраненными данными
+// или с параллельной транзакцией, не прошедшей и после повторов
+const (
+	pgUniqueViolation      = "23505"
+	pgExclusionViolation   = "23P01"
+	pgSerializationFailure = "40001"
+)
+
+// mapRepoError переводит ошибки репозитория в типизированные ошибки сервиса, сохраняя исходную причину
+func mapRepoError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrPreconditionFailed) {
+		return err
+	}
+
+	// Пустой результат QueryRow, не переведенный репозиторием
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && (pgErr.Code == pgUniqueViolation || pgErr.Code == pgExclusionViolation ||
+		pgErr.Code == pgSerializationFailure) {
+		return fmt.Errorf("%w: %w", ErrConflict, err)
+	}
+
+	return err
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+	"subscriptions/internal/entity"
+)
+
+func (s *subService) DeleteExchangeRate(ctx context.Context, currency string, validFrom entity.YearMonth) error {
+
+	currency = normalizeCurrency(currency)
+
+	var v validator
+	v.exchangeRateKey(currency, validFrom)
+
+	if err := v.err(); err != nil {
+		return err
+	}
+
+	return mapRepoError(s.repo.DeleteExchangeRate(ctx, currency, validFrom))
+}
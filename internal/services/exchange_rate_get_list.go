@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"subscriptions/internal/entity"
+)
+
+func (s *subService) GetExchangeRates(ctx context.Context, currency string) ([]entity.ExchangeRate, error) {
+
+	// Пустая валюта означает курсы всех валют
+	currency = strings.ToUpper(currency)
+
+	var v validator
+	if currency != "" {
+		v.currency("currency", currency)
+	}
+
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetExchangeRates(ctx, currency)
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpsertExchangeRate_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	rate := &entity.ExchangeRate{Currency: "USD", ValidFrom: yearMonth("01-2025"), Rate: 92_500_000}
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	mockRepo.EXPECT().UpsertExchangeRate(ctx, rate).
+		Return(rate, nil).Times(1)
+
+	service := New(mockRepo)
+
+	result, err := service.UpsertExchangeRate(ctx, rate)
+
+	require.NoError(t, err)
+	assert.Equal(t, rate, result)
+}
+
+func TestUpsertExchangeRate_Fail_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	service := New(mockRepo)
+
+	result, err := service.UpsertExchangeRate(context.Background(), &entity.ExchangeRate{Currency: "rub", ValidFrom: yearMonth("01-2025"), Rate: 0})
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []FieldError{
+		{Field: "currency", Message: "must be an ISO 4217 code other than RUB"},
+		{Field: "rate", Message: "must be positive"},
+	}, validationErr.Fields)
+	assert.Nil(t, result)
+}
+
+func TestUpsertExchangeRate_Fail_RateOutOfRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	service := New(mockRepo)
+
+	// Такой курс переполнил бы колонку NUMERIC(18, 6). Курс меньше 0.000001 не разбирается из JSON
+	_, err := service.UpsertExchangeRate(context.Background(), &entity.ExchangeRate{Currency: "USD", ValidFrom: yearMonth("01-2025"), Rate: maxExchangeRate + 1})
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []FieldError{
+		{Field: "rate", Message: "must not exceed 999999999999.999999"},
+	}, validationErr.Fields)
+}
+
+func TestGetExchangeRates_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	expectedRates := []entity.ExchangeRate{
+		{Currency: "USD", ValidFrom: yearMonth("01-2025"), Rate: 92_500_000},
+		{Currency: "USD", ValidFrom: yearMonth("06-2025"), Rate: 80_000_000},
+	}
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	mockRepo.EXPECT().GetExchangeRates(ctx, "USD").
+		Return(expectedRates, nil).Times(1)
+
+	service := New(mockRepo)
+
+	rates, err := service.GetExchangeRates(ctx, "USD")
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedRates, rates)
+}
+
+func TestDeleteExchangeRate_Fail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	mockRepo.EXPECT().DeleteExchangeRate(ctx, "USD", yearMonth("01-2025")).
+		Return(fmt.Errorf("internal server error")).Times(1)
+
+	service := New(mockRepo)
+
+	err := service.DeleteExchangeRate(ctx, "USD", yearMonth("01-2025"))
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"context"
+	"subscriptions/internal/entity"
+)
+
+func (s *subService) UpsertExchangeRate(ctx context.Context, rate *entity.ExchangeRate) (*entity.ExchangeRate, error) {
+
+	rate.Currency = normalizeCurrency(rate.Currency)
+
+	var v validator
+	v.exchangeRateKey(rate.Currency, rate.ValidFrom)
+	v.check(rate.Rate > 0, "rate", "must be positive")
+	v.check(rate.Rate <= maxExchangeRate, "rate", "must not exceed "+maxExchangeRate.String())
+
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.UpsertExchangeRate(ctx, rate)
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"subscriptions/internal/entity"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metricsService — декоратор Service, который считает вызовы и их длительность по методам
+type metricsService struct {
+	next     Service
+	calls    *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// WithMetrics оборачивает сервис сбором метрик и регистрирует их в reg
+func WithMetrics(next Service, reg prometheus.Registerer) Service {
+	s := &metricsService{
+		next: next,
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "service_calls_total",
+			Help: "Number of service method calls by result",
+		}, []string{"method", "result"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "service_call_duration_seconds",
+			Help:    "Duration of service method calls",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method"}),
+	}
+
+	reg.MustRegister(s.calls, s.duration)
+
+	return s
+}
+
+// observe вызывается через defer, поэтому получает ошибку по указателю уже после возврата из метода
+func (s *metricsService) observe(method string, start time.Time, err *error) {
+	s.duration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	s.calls.WithLabelValues(method, callResult(*err)).Inc()
+}
+
+// callResult сводит ошибку к небольшому набору значений метки, чтобы ряды не зависели от текста ошибок
+func callResult(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, ErrValidation):
+		return "validation_error"
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ErrConflict):
+		return "conflict"
+	case errors.Is(err, ErrPreconditionFailed):
+		return "precondition_failed"
+	case errors.Is(err, ErrPriceRewrite):
+		return "price_rewrite"
+	default:
+		return "error"
+	}
+}
+
+func (s *metricsService) Create(ctx context.Context, sub *entity.Subscription) (_ *entity.Subscription, err error) {
+	defer s.observe("Create", time.Now(), &err)
+	return s.next.Create(ctx, sub)
+}
+
+func (s *metricsService) GetById(ctx context.Context, id string) (_ *entity.Subscription, err error) {
+	defer s.observe("GetById", time.Now(), &err)
+	return s.next.GetById(ctx, id)
+}
+
+func (s *metricsService) UpdateById(ctx context.Context, sub *entity.Subscription) (_ *entity.Subscription, err error) {
+	defer s.observe("UpdateById", time.Now(), &err)
+	return s.next.UpdateById(ctx, sub)
+}
+
+func (s *metricsService) PatchById(ctx context.Context, id string, patch entity.SubscriptionPatch, version int64) (_ *entity.Subscription, err error) {
+	defer s.observe("PatchById", time.Now(), &err)
+	return s.next.PatchById(ctx, id, patch, version)
+}
+
+func (s *metricsService) DeleteById(ctx context.Context, id string, version int64) (err error) {
+	defer s.observe("DeleteById", time.Now(), &err)
+	return s.next.DeleteById(ctx, id, version)
+}
+
+func (s *metricsService) RestoreById(ctx context.Context, id string, version int64) (_ *entity.Subscription, err error) {
+	defer s.observe("RestoreById", time.Now(), &err)
+	return s.next.RestoreById(ctx, id, version)
+}
+
+func (s *metricsService) GetHistory(ctx context.Context, id string, pagination entity.Pagination) (_ *entity.SubscriptionHistory, err error) {
+	defer s.observe("GetHistory", time.Now(), &err)
+	return s.next.GetHistory(ctx, id, pagination)
+}
+
+func (s *metricsService) AddPriceChange(ctx context.Context, change *entity.PriceChange) (_ *entity.PriceChange, err error) {
+	defer s.observe("AddPriceChange", time.Now(), &err)
+	return s.next.AddPriceChange(ctx, change)
+}
+
+func (s *metricsService) GetPriceChanges(ctx context.Context, id string) (_ []entity.PriceChange, err error) {
+	defer s.observe("GetPriceChanges", time.Now(), &err)
+	return s.next.GetPriceChanges(ctx, id)
+}
+
+func (s *metricsService) GetList(ctx context.Context, filter entity.SubscriptionFilter, sort entity.Sort, pagination entity.Pagination) (_ *entity.SubscriptionList, err error) {
+	defer s.observe("GetList", time.Now(), &err)
+	return s.next.GetList(ctx, filter, sort, pagination)
+}
+
+func (s *metricsService) GetSummary(ctx context.Context, userId string, serviceName string, startDate entity.YearMonth, endDate *entity.YearMonth, currency string) (_ *entity.Summary, err error) {
+	defer s.observe("GetSummary", time.Now(), &err)
+	return s.next.GetSummary(ctx, userId, serviceName, startDate, endDate, currency)
+}
+
+func (s *metricsService) GetUserSummary(ctx context.Context, userId string, serviceName string, startDate entity.YearMonth, endDate *entity.YearMonth, currency string) (_ *entity.UserSummary, err error) {
+	defer s.observe("GetUserSummary", time.Now(), &err)
+	return s.next.GetUserSummary(ctx, userId, serviceName, startDate, endDate, currency)
+}
+
+func (s *metricsService) GetMonthlyBreakdown(ctx context.Context, userId string, startDate entity.YearMonth, endDate *entity.YearMonth, currency string) (_ []entity.MonthlyCost, err error) {
+	defer s.observe("GetMonthlyBreakdown", time.Now(), &err)
+	return s.next.GetMonthlyBreakdown(ctx, userId, startDate, endDate, currency)
+}
+
+func (s *metricsService) UpsertExchangeRate(ctx context.Context, rate *entity.ExchangeRate) (_ *entity.ExchangeRate, err error) {
+	defer s.observe("UpsertExchangeRate", time.Now(), &err)
+	return s.next.UpsertExchangeRate(ctx, rate)
+}
+
+func (s *metricsService) GetExchangeRates(ctx context.Context, currency string) (_ []entity.ExchangeRate, err error) {
+	defer s.observe("GetExchangeRates", time.Now(), &err)
+	return s.next.GetExchangeRates(ctx, currency)
+}
+
+func (s *metricsService) DeleteExchangeRate(ctx context.Context, currency string, validFrom entity.YearMonth) (err error) {
+	defer s.observe("DeleteExchangeRate", time.Now(), &err)
+	return s.next.DeleteExchangeRate(ctx, currency, validFrom)
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWithMetrics_CountsCallsByResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	subId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockRepo.EXPECT().GetById(ctx, subId).Return(&entity.Subscription{Id: subId}, nil)
+	mockRepo.EXPECT().GetById(ctx, subId).Return(nil, entity.ErrNotFound)
+
+	reg := prometheus.NewRegistry()
+	service := WithMetrics(New(mockRepo), reg).(*metricsService)
+
+	_, err := service.GetById(ctx, subId)
+	assert.NoError(t, err)
+
+	_, err = service.GetById(ctx, subId)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = service.GetById(ctx, "not-a-uuid")
+	assert.ErrorIs(t, err, ErrValidation)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(service.calls.WithLabelValues("GetById", "ok")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(service.calls.WithLabelValues("GetById", "not_found")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(service.calls.WithLabelValues("GetById", "validation_error")))
+	assert.Equal(t, 1, testutil.CollectAndCount(service.duration))
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"subscriptions/internal/repositories"
+	"subscriptions/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// purgeBatchSize — сколько подписок очистка удаляет одним запросом
+const purgeBatchSize = 500
+
+// Purger периодически окончательно удаляет подписки, мягко удаленные раньше срока хранения.
+// Несколько экземпляров сервиса могут чистить одновременно: повторное удаление ничего не ломает
+type Purger struct {
+	repo      repositories.Repository
+	retention time.Duration
+	interval  time.Duration
+	now       func() time.Time
+}
+
+func NewPurger(repo repositories.Repository, retention, interval time.Duration) *Purger {
+	return &Purger{repo: repo, retention: retention, interval: interval, now: time.Now}
+}
+
+// Run чистит подписки сразу и затем раз в interval, пока не отменен ctx
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := p.PurgeOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.GetLoggerFromCtx(ctx).Error(ctx, "failed to purge deleted subscriptions", zap.Error(err))
+		}
+		if purged > 0 {
+			logger.GetLoggerFromCtx(ctx).Info(ctx, "purged deleted subscriptions", zap.Int64("count", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeOnce удаляет пачками все подписки, срок хранения которых истек, и возвращает их число
+func (p *Purger) PurgeOnce(ctx context.Context) (int64, error) {
+	before := p.now().Add(-p.retention)
+
+	var total int64
+	for {
+		purged, err := p.repo.PurgeDeleted(ctx, before, purgeBatchSize)
+		total += purged
+		if err != nil {
+			return total, err
+		}
+
+		// Неполная пачка — удалять больше нечего
+		if purged < purgeBatchSize {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPurger_PurgeOnce_DeletesInBatchesUntilPartialBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	now := time.Date(2025, 7, 31, 12, 0, 0, 0, time.UTC)
+	before := now.Add(-30 * 24 * time.Hour)
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	gomock.InOrder(
+		mockRepo.EXPECT().PurgeDeleted(ctx, before, purgeBatchSize).Return(int64(purgeBatchSize), nil),
+		mockRepo.EXPECT().PurgeDeleted(ctx, before, purgeBatchSize).Return(int64(7), nil),
+	)
+
+	purger := NewPurger(mockRepo, 30*24*time.Hour, time.Hour)
+	purger.now = func() time.Time { return now }
+
+	purged, err := purger.PurgeOnce(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(purgeBatchSize+7), purged)
+}
+
+func TestPurger_PurgeOnce_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockRepo.EXPECT().PurgeDeleted(ctx, gomock.Any(), purgeBatchSize).Return(int64(0), assert.AnError)
+
+	purged, err := NewPurger(mockRepo, time.Hour, time.Hour).PurgeOnce(ctx)
+
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Zero(t, purged)
+}
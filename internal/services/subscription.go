@@ -11,9 +11,20 @@ type Service interface {
 	Create(ctx context.Context, sub *entity.Subscription) (*entity.Subscription, error)
 	GetById(ctx context.Context, id string) (*entity.Subscription, error)
 	UpdateById(ctx context.Context, sub *entity.Subscription) (*entity.Subscription, error)
-	DeleteById(ctx context.Context, id string) error
-	GetList(ctx context.Context, page, limit int, userID, serviceName string) ([]entity.Subscription, bool, error)
-	GetSummary(ctx context.Context, userId string, serviceName string, startDate string, endDate string) (int, error)
+	PatchById(ctx context.Context, id string, patch entity.SubscriptionPatch, version int64) (*entity.Subscription, error)
+	DeleteById(ctx context.Context, id string, version int64) error
+	RestoreById(ctx context.Context, id string, version int64) (*entity.Subscription, error)
+	GetHistory(ctx context.Context, id string, pagination entity.Pagination) (*entity.SubscriptionHistory, error)
+	AddPriceChange(ctx context.Context, change *entity.PriceChange) (*entity.PriceChange, error)
+	GetPriceChanges(ctx context.Context, id string) ([]entity.PriceChange, error)
+	GetList(ctx context.Context, filter entity.SubscriptionFilter, sort entity.Sort, pagination entity.Pagination) (*entity.SubscriptionList, error)
+	GetSummary(ctx context.Context, userId string, serviceName string, startDate entity.YearMonth, endDate *entity.YearMonth, currency string) (*entity.Summary, error)
+	GetUserSummary(ctx context.Context, userId string, serviceName string, startDate entity.YearMonth, endDate *entity.YearMonth, currency string) (*entity.UserSummary, error)
+	GetMonthlyBreakdown(ctx context.Context, userId string, startDate entity.YearMonth, endDate *entity.YearMonth, currency string) ([]entity.MonthlyCost, error)
+
+	UpsertExchangeRate(ctx context.Context, rate *entity.ExchangeRate) (*entity.ExchangeRate, error)
+	GetExchangeRates(ctx context.Context, currency string) ([]entity.ExchangeRate, error)
+	DeleteExchangeRate(ctx context.Context, currency string, validFrom entity.YearMonth) error
 }
 
 type subService struct {
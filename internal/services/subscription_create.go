@@ -7,6 +7,19 @@ import (
 
 func (s *subService) Create(ctx context.Context, subIn *entity.Subscription) (*entity.Subscription, error) {
 
-	return s.repo.Create(ctx, subIn)
+	applySubscriptionDefaults(subIn)
 
+	var v validator
+	v.subscription(subIn)
+
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	sub, err := s.repo.Create(ctx, subIn)
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	return sub, nil
 }
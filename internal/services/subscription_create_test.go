@@ -23,19 +23,19 @@ func TestCreateSubscription_Success(t *testing.T) {
 
 	subIn := &entity.Subscription{
 		Name:      "Yandex Plus",
-		Price:     1500,
+		Price:     entity.Money{Amount: 1500, Currency: "RUB"},
 		UserId:    userId,
-		StartDate: "01-2025",
-		EndDate:   "12-2025",
+		StartDate: yearMonth("01-2025"),
+		EndDate:   yearMonthPtr("12-2025"),
 	}
 
 	subOut := &entity.Subscription{
 		Id:        "d6d273fa-486e-4d74-94e0-94dd9b95a1d8",
 		Name:      "Yandex Plus",
-		Price:     1500,
+		Price:     entity.Money{Amount: 1500, Currency: "RUB"},
 		UserId:    userId,
-		StartDate: "01-2025",
-		EndDate:   "12-2025",
+		StartDate: yearMonth("01-2025"),
+		EndDate:   yearMonthPtr("12-2025"),
 	}
 
 	mockRepo := mocks.NewMockRepository(ctrl)
@@ -59,22 +59,49 @@ func TestCreateSubscription_Fail_Validation(t *testing.T) {
 
 	subIn := &entity.Subscription{
 		Name:      "Yandex Plus",
-		Price:     1500,
+		Price:     entity.Money{Amount: 1500, Currency: "RUB"},
 		UserId:    userId,
-		StartDate: "01-2025",
-		EndDate:   "invalid", 
+		StartDate: yearMonth("01-2025"),
+		EndDate:   yearMonthPtr("12-2024"),
+	}
+
+	// До репозитория невалидная подписка не доходит
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	service := New(mockRepo)
+
+	sub, err := service.Create(ctx, subIn)
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.ErrorIs(t, err, ErrValidation)
+	require.Equal(t, []FieldError{{Field: "end_date", Message: "must not be before start_date"}}, validationErr.Fields)
+	require.Nil(t, sub)
+}
+
+func TestCreateSubscription_Fail_RepoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	subIn := &entity.Subscription{
+		Name:      "Yandex Plus",
+		Price:     entity.Money{Amount: 1500, Currency: "RUB"},
+		UserId:    userId,
+		StartDate: yearMonth("01-2025"),
 	}
 
 	mockRepo := mocks.NewMockRepository(ctrl)
 	mockRepo.EXPECT().Create(ctx, subIn).
-		Return(nil, fmt.Errorf("invalid date range")).Times(1)
+		Return(nil, fmt.Errorf("connection refused")).Times(1)
 
 	service := New(mockRepo)
 
-	
 	sub, err := service.Create(ctx, subIn)
 
-	require.Error(t, err) 
-	require.Equal(t, "invalid date range", err.Error())
-	require.Nil(t, sub) 
+	require.Error(t, err)
+	require.Equal(t, "connection refused", err.Error())
+	require.Nil(t, sub)
 }
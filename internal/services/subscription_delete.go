@@ -4,18 +4,21 @@ import (
 	"context"
 )
 
-func (s *subService) DeleteById(ctx context.Context, id string) error {
+// DeleteById удаляет подписку. Ненулевая version удаляет ее, только если подписка не менялась с этой версии
+func (s *subService) DeleteById(ctx context.Context, id string, version int64) error {
 
-	_, err := s.repo.GetById(ctx, id)
+	var v validator
+	v.uuid("id", id)
 
-	if err != nil {
+	if err := v.err(); err != nil {
 		return err
 	}
 
-	err = s.repo.DeleteById(ctx, id)
+	// Отсутствие подписки репозиторий определяет по числу удаленных строк, отдельная проверка GetById гонялась бы с параллельным удалением
+	err := s.repo.DeleteById(ctx, id, version)
 
 	if err != nil {
-		return err
+		return mapRepoError(err)
 	}
 
 	return nil
@@ -21,17 +21,13 @@ func TestDeleteById_Success(t *testing.T) {
 
 	mockRepo := mocks.NewMockRepository(ctrl)
 
-	mockRepo.EXPECT().GetById(ctx, subId).
-		Return(&entity.Subscription{Id: subId}, nil).Times(1)
-
-	mockRepo.EXPECT().DeleteById(ctx, subId).
+	mockRepo.EXPECT().DeleteById(ctx, subId, int64(0)).
 		Return(nil).Times(1)
 
 	service := New(mockRepo)
 
-	err := service.DeleteById(ctx, subId)
+	err := service.DeleteById(ctx, subId, 0)
 
-	
 	require.NoError(t, err)
 }
 
@@ -44,15 +40,16 @@ func TestDeleteById_Fail_NotFound(t *testing.T) {
 
 	mockRepo := mocks.NewMockRepository(ctrl)
 
-	mockRepo.EXPECT().GetById(ctx, subId).
-		Return(nil, fmt.Errorf("subscription not found")).Times(1)
+	// Отсутствие подписки определяется по числу удаленных строк, без предварительного GetById
+	mockRepo.EXPECT().DeleteById(ctx, subId, int64(0)).
+		Return(fmt.Errorf("subscription %s: %w", subId, entity.ErrNotFound)).Times(1)
 
 	service := New(mockRepo)
 
-	err := service.DeleteById(ctx, subId)
+	err := service.DeleteById(ctx, subId, 0)
 
 	require.Error(t, err)
-	assert.Equal(t, "subscription not found", err.Error())
+	assert.ErrorIs(t, err, ErrNotFound)
 }
 
 func TestDeleteById_Fail_DeleteError(t *testing.T) {
@@ -64,16 +61,13 @@ func TestDeleteById_Fail_DeleteError(t *testing.T) {
 
 	mockRepo := mocks.NewMockRepository(ctrl)
 
-	mockRepo.EXPECT().GetById(ctx, subId).
-		Return(&entity.Subscription{Id: subId}, nil).Times(1)
-
-	mockRepo.EXPECT().DeleteById(ctx, subId).
+	mockRepo.EXPECT().DeleteById(ctx, subId, int64(0)).
 		Return(fmt.Errorf("deletion error")).Times(1)
 
 	service := New(mockRepo)
 
-	err := service.DeleteById(ctx, subId)
+	err := service.DeleteById(ctx, subId, 0)
 
-	require.Error(t, err) 
+	require.Error(t, err)
 	assert.Equal(t, "deletion error", err.Error())
 }
@@ -7,5 +7,17 @@ import (
 
 func (s *subService) GetById(ctx context.Context, id string) (*entity.Subscription, error) {
 
-	return s.repo.GetById(ctx, id)
+	var v validator
+	v.uuid("id", id)
+
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	sub, err := s.repo.GetById(ctx, id)
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	return sub, nil
 }
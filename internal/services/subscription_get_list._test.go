@@ -8,12 +8,13 @@ import (
 
 	"testing"
 
-
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
+var idAsc = entity.Sort{Key: entity.SortKeyId, Order: entity.SortAsc}
+
 func TestGetList_Success_WithNextPage(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -23,26 +24,27 @@ func TestGetList_Success_WithNextPage(t *testing.T) {
 	serviceName := "Yandex Plus"
 	page := 1
 	limit := 2
+	filter := entity.SubscriptionFilter{UserId: userID, ServiceName: serviceName}
 
 	expectedSubs := []entity.Subscription{
-		{Id: "1", Name: "Yandex Plus", Price: 1500, UserId: userID},
-		{Id: "2", Name: "Yandex Music", Price: 700, UserId: userID},
-		{Id: "3", Name: "Yandex Video", Price: 500, UserId: userID},
+		{Id: "1", Name: "Yandex Plus", Price: entity.Money{Amount: 1500, Currency: "RUB"}, UserId: userID},
+		{Id: "2", Name: "Yandex Music", Price: entity.Money{Amount: 700, Currency: "RUB"}, UserId: userID},
+		{Id: "3", Name: "Yandex Video", Price: entity.Money{Amount: 500, Currency: "RUB"}, UserId: userID},
 	}
 
 	mockRepo := mocks.NewMockRepository(ctrl)
 
-
-	mockRepo.EXPECT().GetList(ctx, 0, limit+1, userID, serviceName).
+	mockRepo.EXPECT().GetList(ctx, filter, idAsc, entity.ListPage{Limit: limit + 1}).
 		Return(expectedSubs, nil).Times(1)
 
 	service := New(mockRepo)
 
-	subs, hasNext, err := service.GetList(ctx, page, limit, userID, serviceName)
+	list, err := service.GetList(ctx, filter, entity.Sort{}, entity.Pagination{Page: page, Limit: limit})
 
 	require.NoError(t, err)
-	assert.Equal(t, 2, len(subs))
-	assert.True(t, hasNext)
+	assert.Equal(t, 2, len(list.Subscriptions))
+	assert.True(t, list.HasNext)
+	assert.NotEmpty(t, list.NextCursor)
 }
 
 func TestGetList_Success_WithoutNextPage(t *testing.T) {
@@ -54,24 +56,26 @@ func TestGetList_Success_WithoutNextPage(t *testing.T) {
 	serviceName := "Yandex Plus"
 	page := 1
 	limit := 2
+	filter := entity.SubscriptionFilter{UserId: userID, ServiceName: serviceName}
 
 	expectedSubs := []entity.Subscription{
-		{Id: "1", Name: "Yandex Plus", Price: 1500, UserId: userID},
-		{Id: "2", Name: "Yandex Music", Price: 700, UserId: userID},
+		{Id: "1", Name: "Yandex Plus", Price: entity.Money{Amount: 1500, Currency: "RUB"}, UserId: userID},
+		{Id: "2", Name: "Yandex Music", Price: entity.Money{Amount: 700, Currency: "RUB"}, UserId: userID},
 	}
 
 	mockRepo := mocks.NewMockRepository(ctrl)
 
-	mockRepo.EXPECT().GetList(ctx, 0, limit+1, userID, serviceName).
+	mockRepo.EXPECT().GetList(ctx, filter, idAsc, entity.ListPage{Limit: limit + 1}).
 		Return(expectedSubs, nil).Times(1)
 
 	service := New(mockRepo)
 
-	subs, hasNext, err := service.GetList(ctx, page, limit, userID, serviceName)
+	list, err := service.GetList(ctx, filter, entity.Sort{}, entity.Pagination{Page: page, Limit: limit})
 
-	require.NoError(t, err)      
-	assert.Equal(t, 2, len(subs))
-	assert.False(t, hasNext)      
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(list.Subscriptions))
+	assert.False(t, list.HasNext)
+	assert.Empty(t, list.NextCursor)
 }
 
 func TestGetList_Fail(t *testing.T) {
@@ -83,18 +87,149 @@ func TestGetList_Fail(t *testing.T) {
 	serviceName := "Yandex Plus"
 	page := 1
 	limit := 2
+	filter := entity.SubscriptionFilter{UserId: userID, ServiceName: serviceName}
 
 	mockRepo := mocks.NewMockRepository(ctrl)
 
-	mockRepo.EXPECT().GetList(ctx, 0, limit+1, userID, serviceName).
+	mockRepo.EXPECT().GetList(ctx, filter, idAsc, entity.ListPage{Limit: limit + 1}).
 		Return(nil, fmt.Errorf("internal server error")).Times(1)
 
 	service := New(mockRepo)
 
-	subs, hasNext, err := service.GetList(ctx, page, limit, userID, serviceName)
+	list, err := service.GetList(ctx, filter, entity.Sort{}, entity.Pagination{Page: page, Limit: limit})
 
 	assert.Error(t, err)
 	assert.Equal(t, "internal server error", err.Error())
-	assert.Nil(t, subs)
-	assert.False(t, hasNext)
+	assert.Nil(t, list)
+}
+
+func TestGetList_Success_WithCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	limit := 1
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	// Первая страница без курсора, вторая — после последней записи первой
+	filter := entity.SubscriptionFilter{UserId: userID}
+
+	mockRepo.EXPECT().GetList(ctx, filter, idAsc, entity.ListPage{Limit: limit + 1}).
+		Return([]entity.Subscription{{Id: "1"}, {Id: "2"}}, nil).Times(1)
+	mockRepo.EXPECT().GetList(ctx, filter, idAsc, entity.ListPage{
+		Limit: limit + 1,
+		After: &entity.Cursor{SortKey: entity.SortKeyId, Order: entity.SortAsc, LastId: "1"},
+	}).
+		Return([]entity.Subscription{{Id: "2"}}, nil).Times(1)
+
+	service := New(mockRepo)
+
+	first, err := service.GetList(ctx, filter, entity.Sort{}, entity.Pagination{Limit: limit})
+	require.NoError(t, err)
+	require.True(t, first.HasNext)
+
+	second, err := service.GetList(ctx, filter, entity.Sort{}, entity.Pagination{Limit: limit, Cursor: first.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, "2", second.Subscriptions[0].Id)
+	assert.False(t, second.HasNext)
+	assert.Empty(t, second.NextCursor)
+}
+
+func TestGetList_Fail_InvalidCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := New(mockRepo)
+
+	for _, pagination := range []entity.Pagination{
+		{Limit: 10, Cursor: "not-a-cursor"},
+		{Limit: 10, Cursor: encodeCursor(entity.Cursor{SortKey: "price", Order: entity.SortAsc, LastId: "1"})},
+		{Limit: 10, Page: 2, Cursor: encodeCursor(entity.Cursor{SortKey: entity.SortKeyId, Order: entity.SortAsc, LastId: "1"})},
+	} {
+		_, err := service.GetList(ctx, entity.SubscriptionFilter{}, entity.Sort{}, pagination)
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "cursor", validationErr.Fields[0].Field)
+	}
+}
+
+func TestGetList_Success_SortCursorCarriesLastValue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	sort := entity.Sort{Key: entity.SortKeyPrice, Order: entity.SortDesc}
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockRepo.EXPECT().GetList(ctx, entity.SubscriptionFilter{}, sort, entity.ListPage{Limit: 2}).
+		Return([]entity.Subscription{
+			{Id: "1", Price: entity.Money{Amount: 99900}},
+			{Id: "2", Price: entity.Money{Amount: 50000}},
+		}, nil).Times(1)
+
+	service := New(mockRepo)
+
+	list, err := service.GetList(ctx, entity.SubscriptionFilter{}, sort, entity.Pagination{Limit: 1})
+	require.NoError(t, err)
+
+	cursor, err := decodeCursor(list.NextCursor, sort)
+	require.NoError(t, err)
+	assert.Equal(t, "99900", cursor.LastValue)
+	assert.Equal(t, "1", cursor.LastId)
+}
+
+func TestGetList_Fail_InvalidFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	priceMin := entity.Amount(1000)
+	priceMax := entity.Amount(500)
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := New(mockRepo)
+
+	filter := entity.SubscriptionFilter{PriceMin: &priceMin, PriceMax: &priceMax, Status: "paused"}
+	sort := entity.Sort{Key: "user_id", Order: "up"}
+
+	_, err := service.GetList(ctx, filter, sort, entity.Pagination{Limit: 10})
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+
+	fields := make([]string, 0, len(validationErr.Fields))
+	for _, f := range validationErr.Fields {
+		fields = append(fields, f.Field)
+	}
+	assert.ElementsMatch(t, []string{"price_max", "status", "sort", "order"}, fields)
+}
+
+func TestGetList_Success_EmptyWithTotal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	filter := entity.SubscriptionFilter{UserId: "60601fee-2bf1-4721-ae6f-7636e79a0cba"}
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTx(mockRepo, entity.TxOptions{Isolation: entity.RepeatableRead, ReadOnly: true})
+	mockRepo.EXPECT().GetList(ctx, filter, idAsc, entity.ListPage{Limit: 11}).
+		Return([]entity.Subscription{}, nil).Times(1)
+	mockRepo.EXPECT().CountList(ctx, filter).Return(0, nil).Times(1)
+
+	service := New(mockRepo)
+
+	list, err := service.GetList(ctx, filter, entity.Sort{}, entity.Pagination{Limit: 10, WithTotal: true})
+
+	require.NoError(t, err)
+	assert.Empty(t, list.Subscriptions)
+	assert.False(t, list.HasNext)
+	require.NotNil(t, list.TotalCount)
+	assert.Equal(t, 0, *list.TotalCount)
 }
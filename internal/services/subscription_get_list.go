@@ -5,23 +5,82 @@ import (
 	"subscriptions/internal/entity"
 )
 
-func (s *subService) GetList(ctx context.Context, page, limit int,
-	userID, serviceName string) ([]entity.Subscription, bool, error) {
+func (s *subService) GetList(ctx context.Context, filter entity.SubscriptionFilter, sort entity.Sort,
+	pagination entity.Pagination) (*entity.SubscriptionList, error) {
 
-	offset := (page - 1) * limit
+	// Без sort список упорядочен по id по возрастанию
+	if sort.Key == "" {
+		sort.Key = entity.SortKeyId
+	}
+	if sort.Order == "" {
+		sort.Order = entity.SortAsc
+	}
+
+	var v validator
+	v.listFilter(filter)
+	v.check(entity.IsValidSortKey(sort.Key), "sort", "must be one of id, price, start_date, end_date, service_name, updated_at")
+	v.check(sort.Order.IsValid(), "order", "must be asc or desc")
+	v.check(pagination.Page >= 0, "page", "must be positive")
+	v.check(pagination.Limit >= 1 && pagination.Limit <= maxListLimit, "limit", "must be between 1 and 100")
+	v.check(pagination.Page == 0 || pagination.Cursor == "", "cursor", "must not be combined with page")
+
+	var after *entity.Cursor
+	if pagination.Cursor != "" {
+		var err error
+		after, err = decodeCursor(pagination.Cursor, sort)
+		v.check(err == nil, "cursor", "is invalid or was issued for another sort order")
+	}
+
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	limit := pagination.Limit
 
 	//limit+1 для hasNext в ответе
-	subs, err := s.repo.GetList(ctx, offset, limit+1, userID, serviceName)
+	page := entity.ListPage{Limit: limit + 1, After: after}
+	if pagination.Page > 0 {
+		page.Offset = (pagination.Page - 1) * limit
+	}
+
+	var subs []entity.Subscription
+	var total int
+
+	// Страница и общее число читаются из одного снимка, иначе total_count мог бы не сойтись со страницей
+	load := func(ctx context.Context) error {
+		var err error
+		subs, err = s.repo.GetList(ctx, filter, sort, page)
+		if err != nil || !pagination.WithTotal {
+			return err
+		}
+
+		total, err = s.repo.CountList(ctx, filter)
+		return err
+	}
+
+	var err error
+	if pagination.WithTotal {
+		err = s.repo.WithTx(ctx, entity.TxOptions{Isolation: entity.RepeatableRead, ReadOnly: true}, load)
+	} else {
+		err = load(ctx)
+	}
 	if err != nil {
-		return nil, false, err
+		return nil, mapRepoError(err)
 	}
 
-	//Используем hasNext, чтобы не выполнять тяжеловесный count(*) по всей таблице 
-	hasNext := false
+	//Используем hasNext, чтобы не выполнять тяжеловесный count(*) по всей таблице
+	list := &entity.SubscriptionList{Subscriptions: subs}
 	if len(subs) > limit {
-		hasNext = true
-		subs = subs[:limit]
+		list.HasNext = true
+		list.Subscriptions = subs[:limit]
+
+		// Курсор выдается и в режиме page, чтобы клиент мог перейти на keyset-пагинацию с любой страницы
+		list.NextCursor = nextCursor(list.Subscriptions[limit-1], sort)
+	}
+
+	if pagination.WithTotal {
+		list.TotalCount = &total
 	}
 
-	return subs, hasNext, nil
+	return list, nil
 }
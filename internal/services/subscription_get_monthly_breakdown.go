@@ -0,0 +1,21 @@
+package services
+
+import (
+	"context"
+	"subscriptions/internal/entity"
+)
+
+func (s *subService) GetMonthlyBreakdown(ctx context.Context, userId string,
+	startDate entity.YearMonth, endDate *entity.YearMonth, currency string) ([]entity.MonthlyCost, error) {
+
+	currency = normalizeCurrency(currency)
+
+	var v validator
+	v.summaryQuery(userId, startDate, endDate, currency)
+
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CalculateMonthlyBreakdown(ctx, userId, startDate, endDate, currency)
+}
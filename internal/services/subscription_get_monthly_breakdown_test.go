@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetMonthlyBreakdown_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	startDate := yearMonth("01-2025")
+	endDate := yearMonthPtr("02-2025")
+
+	sub := entity.Subscription{
+		Id:        "d6d273fa-486e-4d74-94e0-94dd9b95a1d8",
+		Name:      "Yandex Plus",
+		Price:     entity.Money{Amount: 400, Currency: "RUB"},
+		UserId:    userId,
+		StartDate: yearMonth("01-2025"),
+	}
+
+	expectedBreakdown := []entity.MonthlyCost{
+		{Month: yearMonth("01-2025"), TotalCost: entity.Money{Amount: 400, Currency: "RUB"}, Subscriptions: []entity.MonthlyCostItem{{Subscription: sub, Cost: entity.Money{Amount: 400, Currency: "RUB"}}}},
+		{Month: yearMonth("02-2025"), TotalCost: entity.Money{Amount: 400, Currency: "RUB"}, Subscriptions: []entity.MonthlyCostItem{{Subscription: sub, Cost: entity.Money{Amount: 400, Currency: "RUB"}}}},
+	}
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	mockRepo.EXPECT().CalculateMonthlyBreakdown(ctx, userId, startDate, endDate, "USD").
+		Return(expectedBreakdown, nil).Times(1)
+
+	service := New(mockRepo)
+
+	breakdown, err := service.GetMonthlyBreakdown(ctx, userId, startDate, endDate, "USD")
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedBreakdown, breakdown)
+}
+
+func TestGetMonthlyBreakdown_Fail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	startDate := yearMonth("01-2025")
+	endDate := yearMonthPtr("12-2025")
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	mockRepo.EXPECT().CalculateMonthlyBreakdown(ctx, userId, startDate, endDate, "USD").
+		Return(nil, fmt.Errorf("internal server error")).Times(1)
+
+	service := New(mockRepo)
+
+	breakdown, err := service.GetMonthlyBreakdown(ctx, userId, startDate, endDate, "USD")
+
+	assert.Error(t, err)
+	assert.Equal(t, "internal server error", err.Error())
+	assert.Nil(t, breakdown)
+}
@@ -1,9 +1,22 @@
 package services
 
-import "context"
+import (
+	"context"
+	"subscriptions/internal/entity"
+)
 
 func (s *subService) GetSummary(ctx context.Context, userId string, serviceName string,
-	startDate string, endDate string) (int, error) {
+	startDate entity.YearMonth, endDate *entity.YearMonth, currency string) (*entity.Summary, error) {
 
-	return s.repo.CalculateSummary(ctx, userId, serviceName, startDate, endDate)
+	currency = normalizeCurrency(currency)
+
+	var v validator
+	v.summaryQuery(userId, startDate, endDate, currency)
+	v.check(serviceName != "", "service_name", "is required")
+
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CalculateSummary(ctx, userId, serviceName, startDate, endDate, currency)
 }
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"subscriptions/internal/entity"
 	"subscriptions/internal/repositories/mocks"
 	"testing"
 
@@ -11,6 +12,20 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
+// yearMonth разбирает дату MM-YYYY для тестовых данных
+func yearMonth(s string) entity.YearMonth {
+	ym, err := entity.ParseYearMonth(s)
+	if err != nil {
+		panic(err)
+	}
+	return ym
+}
+
+func yearMonthPtr(s string) *entity.YearMonth {
+	ym := yearMonth(s)
+	return &ym
+}
+
 func TestGetSummary_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -18,19 +33,35 @@ func TestGetSummary_Success(t *testing.T) {
 	ctx := context.Background()
 	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
 	serviceName := "Yandex Plus"
-	startDate := "01-2025"
-	endDate := "12-2025"
-
-	expectedSummary := 3200
+	startDate := yearMonth("01-2025")
+	endDate := yearMonthPtr("12-2025")
+
+	expectedSummary := &entity.Summary{
+		TotalCost: entity.Money{Amount: 3200, Currency: "RUB"},
+		Months:    8,
+		Subscriptions: []entity.SummaryItem{
+			{
+				Subscription: entity.Subscription{
+					Id:        "d6d273fa-486e-4d74-94e0-94dd9b95a1d8",
+					Name:      serviceName,
+					Price:     entity.Money{Amount: 400, Currency: "RUB"},
+					UserId:    userId,
+					StartDate: yearMonth("05-2025"),
+				},
+				Months: 8,
+				Cost:   entity.Money{Amount: 3200, Currency: "RUB"},
+			},
+		},
+	}
 
 	mockRepo := mocks.NewMockRepository(ctrl)
 
-	mockRepo.EXPECT().CalculateSummary(ctx, userId, serviceName, startDate, endDate).
+	mockRepo.EXPECT().CalculateSummary(ctx, userId, serviceName, startDate, endDate, "USD").
 		Return(expectedSummary, nil).Times(1)
 
 	service := New(mockRepo)
 
-	summary, err := service.GetSummary(ctx, userId, serviceName, startDate, endDate)
+	summary, err := service.GetSummary(ctx, userId, serviceName, startDate, endDate, "USD")
 
 	require.NoError(t, err)
 	assert.Equal(t, expectedSummary, summary)
@@ -43,19 +74,56 @@ func TestGetSummary_Fail(t *testing.T) {
 	ctx := context.Background()
 	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
 	serviceName := "Yandex Plus"
-	startDate := "01-2025"
-	endDate := "12-2025"
+	startDate := yearMonth("01-2025")
+	endDate := yearMonthPtr("12-2025")
 
 	mockRepo := mocks.NewMockRepository(ctrl)
 
-	mockRepo.EXPECT().CalculateSummary(ctx, userId, serviceName, startDate, endDate).
-		Return(0, fmt.Errorf("internal server error")).Times(1)
+	mockRepo.EXPECT().CalculateSummary(ctx, userId, serviceName, startDate, endDate, "USD").
+		Return(nil, fmt.Errorf("internal server error")).Times(1)
 
 	service := New(mockRepo)
 
-	summary, err := service.GetSummary(ctx, userId, serviceName, startDate, endDate)
+	summary, err := service.GetSummary(ctx, userId, serviceName, startDate, endDate, "USD")
 
 	assert.Error(t, err)
 	assert.Equal(t, "internal server error", err.Error())
-	assert.Equal(t, 0, summary)
+	assert.Nil(t, summary)
+}
+
+func TestGetSummary_Fail_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	service := New(mockRepo)
+
+	summary, err := service.GetSummary(ctx, "user-123", "", yearMonth("12-2025"), yearMonthPtr("01-2025"), "rubles")
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []FieldError{
+		{Field: "user_id", Message: "must be a valid UUID"},
+		{Field: "end_date", Message: "must not be before start_date"},
+		{Field: "currency", Message: "must be an ISO 4217 code"},
+		{Field: "service_name", Message: "is required"},
+	}, validationErr.Fields)
+	assert.Nil(t, summary)
+}
+
+func TestGetSummary_Fail_FutureStartWithoutEndDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Период до текущего месяца не может начинаться в будущем: до репозитория запрос не доходит
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	service := New(mockRepo)
+	_, err := service.GetSummary(context.Background(), "60601fee-2bf1-4721-ae6f-7636e79a0cba", "Yandex Plus",
+		yearMonth("01-2030"), nil, "")
+
+	assert.ErrorIs(t, err, ErrValidation)
 }
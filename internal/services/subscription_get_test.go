@@ -21,10 +21,10 @@ func TestGetById_Success(t *testing.T) {
 	expectedSub := &entity.Subscription{
 		Id:        subId,
 		Name:      "Yandex Plus",
-		Price:     1500,
+		Price:     entity.Money{Amount: 1500, Currency: "RUB"},
 		UserId:    "60601fee-2bf1-4721-ae6f-7636e79a0cba",
-		StartDate: "01-2025",
-		EndDate:   "12-2025",
+		StartDate: yearMonth("01-2025"),
+		EndDate:   yearMonthPtr("12-2025"),
 	}
 
 	mockRepo := mocks.NewMockRepository(ctrl)
@@ -36,8 +36,8 @@ func TestGetById_Success(t *testing.T) {
 
 	sub, err := service.GetById(ctx, subId)
 
-	require.NoError(t, err)          
-	assert.Equal(t, expectedSub, sub) 
+	require.NoError(t, err)
+	assert.Equal(t, expectedSub, sub)
 }
 
 func TestGetById_Fail_NotFound(t *testing.T) {
@@ -56,7 +56,41 @@ func TestGetById_Fail_NotFound(t *testing.T) {
 
 	sub, err := service.GetById(ctx, subId)
 
-	assert.Error(t, err) 
+	assert.Error(t, err)
 	assert.Equal(t, "subscription not found", err.Error())
-	assert.Nil(t, sub) 
+	assert.Nil(t, sub)
+}
+
+func TestGetById_Fail_InvalidId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	service := New(mockRepo)
+
+	sub, err := service.GetById(context.Background(), "not-a-uuid")
+
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.Nil(t, sub)
+}
+
+func TestGetById_Fail_NoRows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	subId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	mockRepo.EXPECT().GetById(ctx, subId).
+		Return(nil, entity.ErrNotFound).Times(1)
+
+	service := New(mockRepo)
+
+	sub, err := service.GetById(ctx, subId)
+
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Nil(t, sub)
 }
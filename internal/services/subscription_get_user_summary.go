@@ -0,0 +1,21 @@
+package services
+
+import (
+	"context"
+	"subscriptions/internal/entity"
+)
+
+func (s *subService) GetUserSummary(ctx context.Context, userId string, serviceName string,
+	startDate entity.YearMonth, endDate *entity.YearMonth, currency string) (*entity.UserSummary, error) {
+
+	currency = normalizeCurrency(currency)
+
+	var v validator
+	v.summaryQuery(userId, startDate, endDate, currency)
+
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CalculateUserSummary(ctx, userId, serviceName, startDate, endDate, currency)
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetUserSummary_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	startDate := yearMonth("01-2025")
+	endDate := yearMonthPtr("12-2025")
+
+	expectedSummary := &entity.UserSummary{
+		TotalCost: entity.Money{Amount: 8400, Currency: "RUB"},
+		Months:    24,
+		Services: []entity.ServiceSummary{
+			{ServiceName: "Kinopoisk", TotalCost: entity.Money{Amount: 3600, Currency: "RUB"}, Months: 12},
+			{ServiceName: "Yandex Plus", TotalCost: entity.Money{Amount: 4800, Currency: "RUB"}, Months: 12},
+		},
+	}
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	mockRepo.EXPECT().CalculateUserSummary(ctx, userId, "", startDate, endDate, "USD").
+		Return(expectedSummary, nil).Times(1)
+
+	service := New(mockRepo)
+
+	summary, err := service.GetUserSummary(ctx, userId, "", startDate, endDate, "USD")
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedSummary, summary)
+}
+
+func TestGetUserSummary_Fail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	serviceName := "Yandex Plus"
+	startDate := yearMonth("01-2025")
+	endDate := yearMonthPtr("12-2025")
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	mockRepo.EXPECT().CalculateUserSummary(ctx, userId, serviceName, startDate, endDate, "USD").
+		Return(nil, fmt.Errorf("internal server error")).Times(1)
+
+	service := New(mockRepo)
+
+	summary, err := service.GetUserSummary(ctx, userId, serviceName, startDate, endDate, "USD")
+
+	assert.Error(t, err)
+	assert.Equal(t, "internal server error", err.Error())
+	assert.Nil(t, summary)
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"subscriptions/internal/entity"
+)
+
+// Журнал отдается от новых событий к старым, курсор хранит id последнего события страницы
+var historySort = entity.Sort{Key: historySortKey, Order: entity.SortDesc}
+
+// GetHistory возвращает журнал изменений подписки. Журнал переживает окончательное удаление подписки,
+// поэтому 404 означает только то, что по этому id не было ни одного события
+func (s *subService) GetHistory(ctx context.Context, id string, pagination entity.Pagination) (*entity.SubscriptionHistory, error) {
+
+	var v validator
+	v.uuid("id", id)
+	v.check(pagination.Limit >= 1 && pagination.Limit <= maxListLimit, "limit", "must be between 1 and 100")
+
+	var beforeId int64
+	if pagination.Cursor != "" {
+		after, err := decodeCursor(pagination.Cursor, historySort)
+		if err == nil {
+			beforeId, err = strconv.ParseInt(after.LastId, 10, 64)
+		}
+		v.check(err == nil && beforeId > 0, "cursor", "is invalid or was issued for another listing")
+	}
+
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	limit := pagination.Limit
+
+	//limit+1 для hasNext в ответе
+	events, err := s.repo.GetHistory(ctx, id, entity.HistoryPage{Limit: limit + 1, BeforeId: beforeId})
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	// У каждой подписки есть хотя бы событие создания, в том числе у созданных до появления журнала.
+	// Пустой журнал на первой странице значит, что подписки с таким id не существовало
+	if len(events) == 0 && beforeId == 0 {
+		return nil, ErrNotFound
+	}
+
+	history := &entity.SubscriptionHistory{Events: events}
+	if len(events) > limit {
+		history.HasNext = true
+		history.Events = events[:limit]
+		history.NextCursor = encodeCursor(entity.Cursor{
+			SortKey: historySort.Key,
+			Order:   historySort.Order,
+			LastId:  strconv.FormatInt(history.Events[limit-1].Id, 10),
+		})
+	}
+
+	return history, nil
+}
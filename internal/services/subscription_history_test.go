@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetHistory_Success_NextCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	subId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	events := []entity.SubscriptionEvent{
+		{Id: 9, Type: entity.EventDeleted},
+		{Id: 7, Type: entity.EventUpdated},
+		{Id: 3, Type: entity.EventCreated},
+	}
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockRepo.EXPECT().GetHistory(ctx, subId, entity.HistoryPage{Limit: 3}).Return(events, nil).Times(1)
+	mockRepo.EXPECT().GetHistory(ctx, subId, entity.HistoryPage{Limit: 3, BeforeId: 7}).Return(events[2:], nil).Times(1)
+
+	service := New(mockRepo)
+	history, err := service.GetHistory(ctx, subId, entity.Pagination{Limit: 2})
+
+	require.NoError(t, err)
+	assert.True(t, history.HasNext)
+	assert.Len(t, history.Events, 2)
+	require.NotEmpty(t, history.NextCursor)
+
+	next, err := service.GetHistory(ctx, subId, entity.Pagination{Limit: 2, Cursor: history.NextCursor})
+
+	require.NoError(t, err)
+	assert.False(t, next.HasNext)
+	assert.Empty(t, next.NextCursor)
+	assert.Equal(t, int64(3), next.Events[0].Id)
+}
+
+func TestGetHistory_Fail_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	subId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockRepo.EXPECT().GetHistory(ctx, subId, entity.HistoryPage{Limit: 11}).Return([]entity.SubscriptionEvent{}, nil).Times(1)
+
+	service := New(mockRepo)
+	_, err := service.GetHistory(ctx, subId, entity.Pagination{Limit: 10})
+
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGetHistory_Fail_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	// Курсор списка подписок не подходит для журнала
+	listCursor := nextCursor(entity.Subscription{Id: subId}, entity.Sort{Key: entity.SortKeyId, Order: entity.SortDesc})
+
+	tests := []struct {
+		name       string
+		id         string
+		pagination entity.Pagination
+		field      string
+	}{
+		{"invalid id", "not-a-uuid", entity.Pagination{Limit: 10}, "id"},
+		{"limit too large", subId, entity.Pagination{Limit: 101}, "limit"},
+		{"garbage cursor", subId, entity.Pagination{Limit: 10, Cursor: "???"}, "cursor"},
+		{"list cursor", subId, entity.Pagination{Limit: 10, Cursor: listCursor}, "cursor"},
+	}
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := New(mockRepo)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.GetHistory(context.Background(), tt.id, tt.pagination)
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Len(t, validationErr.Fields, 1)
+			assert.Equal(t, tt.field, validationErr.Fields[0].Field)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"subscriptions/internal/entity"
+)
+
+// PatchById меняет только переданные поля подписки. Результат патча проверяется целиком,
+// чтобы, например, новая end_date не оказалась раньше сохраненной start_date.
+// Ненулевая version применяет патч, только если подписка не менялась с этой версии
+func (s *subService) PatchById(ctx context.Context, id string, patch entity.SubscriptionPatch, version int64) (*entity.Subscription, error) {
+
+	var v validator
+	v.uuid("id", id)
+
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	// Патч проверяется на прочитанной подписке и ее изменениях цены, поэтому строка подписки
+	// блокируется до записи: параллельные PATCH, PUT и добавление изменения цены ждут эту транзакцию
+	var subOut *entity.Subscription
+	err := s.repo.WithTx(ctx, entity.TxOptions{Isolation: entity.ReadCommitted}, func(ctx context.Context) error {
+		current, err := s.repo.LockById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		// Версию проверяем и до записи: пустой патч до репозитория не доходит
+		if version != 0 && current.Version != version {
+			return fmt.Errorf("subscription %s: %w", id, ErrPreconditionFailed)
+		}
+
+		// Пустой патч по RFC 7396 ничего не меняет
+		if patch.IsEmpty() {
+			subOut = current
+			return nil
+		}
+
+		if patch.Currency != nil {
+			currency := normalizeCurrency(*patch.Currency)
+			patch.Currency = &currency
+		}
+
+		patched := patch.Apply(*current)
+
+		var v validator
+		v.subscription(&patched)
+
+		if err := v.err(); err != nil {
+			return err
+		}
+
+		changes, err := s.repo.GetPriceChanges(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := checkPriceHistory(current, &patched, changes); err != nil {
+			return err
+		}
+
+		subOut, err = s.repo.Patch(ctx, id, &patch, version)
+		return err
+	})
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	return subOut, nil
+}
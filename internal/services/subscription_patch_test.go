@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func patchTestSubscription() *entity.Subscription {
+	return &entity.Subscription{
+		Id:              "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+		Name:            "Yandex Plus",
+		Price:           entity.Money{Amount: 19900, Currency: "RUB"},
+		UserId:          "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+		StartDate:       yearMonth("01-2025"),
+		EndDate:         yearMonthPtr("12-2025"),
+		BillingPeriod:   entity.BillingPeriodMonth,
+		BillingInterval: 1,
+	}
+}
+
+// expectTx ожидает транзакцию с параметрами opts и выполняет ее тело без базы
+func expectTx(mockRepo *mocks.MockRepository, opts entity.TxOptions) {
+	mockRepo.EXPECT().
+		WithTx(gomock.Any(), opts, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ entity.TxOptions, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		Times(1)
+}
+
+func TestPatchById_Success_ClearEndDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	// Валюту можно сменить, пока подписка не началась
+	current := patchTestSubscription()
+	current.StartDate = entity.CurrentYearMonth().AddMonths(1)
+	currency := "usd"
+
+	patched := *current
+	patched.EndDate = nil
+	patched.Price.Currency = "USD"
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTx(mockRepo, entity.TxOptions{Isolation: entity.ReadCommitted})
+	mockRepo.EXPECT().LockById(ctx, current.Id).Return(current, nil).Times(1)
+	mockRepo.EXPECT().GetPriceChanges(ctx, current.Id).Return([]entity.PriceChange{}, nil).Times(1)
+	// Валюта приводится к верхнему регистру до записи
+	mockRepo.EXPECT().Patch(ctx, current.Id, gomock.Cond(func(p any) bool {
+		patch := p.(*entity.SubscriptionPatch)
+		return patch.ClearEndDate && *patch.Currency == "USD"
+	}), int64(0)).Return(&patched, nil).Times(1)
+
+	service := New(mockRepo)
+	result, err := service.PatchById(ctx, current.Id, entity.SubscriptionPatch{ClearEndDate: true, Currency: &currency}, 0)
+
+	require.NoError(t, err)
+	assert.Nil(t, result.EndDate)
+	assert.Equal(t, "USD", result.Price.Currency)
+}
+
+func TestPatchById_Fail_PatchedSubscriptionInvalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	current := patchTestSubscription()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTx(mockRepo, entity.TxOptions{Isolation: entity.ReadCommitted})
+	mockRepo.EXPECT().LockById(ctx, current.Id).Return(current, nil).Times(1)
+
+	service := New(mockRepo)
+
+	// Новая дата начала позже сохраненной даты окончания
+	_, err := service.PatchById(ctx, current.Id, entity.SubscriptionPatch{StartDate: yearMonthPtr("01-2026")}, 0)
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "end_date", validationErr.Fields[0].Field)
+}
+
+func TestPatchById_Success_EmptyPatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	current := patchTestSubscription()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTx(mockRepo, entity.TxOptions{Isolation: entity.ReadCommitted})
+	mockRepo.EXPECT().LockById(ctx, current.Id).Return(current, nil).Times(1)
+
+	service := New(mockRepo)
+	result, err := service.PatchById(ctx, current.Id, entity.SubscriptionPatch{}, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, current, result)
+}
+
+func TestPatchById_Fail_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	id := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTx(mockRepo, entity.TxOptions{Isolation: entity.ReadCommitted})
+	mockRepo.EXPECT().LockById(ctx, id).Return(nil, entity.ErrNotFound).Times(1)
+
+	service := New(mockRepo)
+	_, err := service.PatchById(ctx, id, entity.SubscriptionPatch{ClearEndDate: true}, 0)
+
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestPatchById_Fail_VersionMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	current := patchTestSubscription()
+	current.Version = 3
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTx(mockRepo, entity.TxOptions{Isolation: entity.ReadCommitted})
+	mockRepo.EXPECT().LockById(ctx, current.Id).Return(current, nil).Times(1)
+
+	service := New(mockRepo)
+
+	// Даже пустой патч не возвращает подписку, если клиент видел другую версию
+	_, err := service.PatchById(ctx, current.Id, entity.SubscriptionPatch{}, 2)
+
+	assert.ErrorIs(t, err, ErrPreconditionFailed)
+}
+
+func TestPatchById_Success_PassesVersionToRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	current := patchTestSubscription()
+	current.Version = 3
+	name := "Kinopoisk"
+
+	patched := *current
+	patched.Name = name
+	patched.Version = 4
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTx(mockRepo, entity.TxOptions{Isolation: entity.ReadCommitted})
+	mockRepo.EXPECT().LockById(ctx, current.Id).Return(current, nil).Times(1)
+	mockRepo.EXPECT().GetPriceChanges(ctx, current.Id).Return([]entity.PriceChange{}, nil).Times(1)
+	// Версия передается в репозиторий, чтобы изменение между чтением и записью тоже обнаружилось
+	mockRepo.EXPECT().Patch(ctx, current.Id, gomock.Any(), int64(3)).Return(&patched, nil).Times(1)
+
+	service := New(mockRepo)
+	result, err := service.PatchById(ctx, current.Id, entity.SubscriptionPatch{Name: &name}, 3)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), result.Version)
+}
+
+func TestPatchById_Fail_SerializationConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	subId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	price := entity.Amount(29900)
+
+	// Транзакция не прошла и после всех повторов
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockRepo.EXPECT().
+		WithTx(gomock.Any(), entity.TxOptions{Isolation: entity.ReadCommitted}, gomock.Any()).
+		Return(&pgconn.PgError{Code: "40001"}).
+		Times(1)
+
+	service := New(mockRepo)
+	_, err := service.PatchById(ctx, subId, entity.SubscriptionPatch{Price: &price}, 0)
+
+	assert.ErrorIs(t, err, ErrConflict)
+}
+
+func TestPatchById_Fail_PriceRewrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	price := entity.Amount(29900)
+	currency := "USD"
+
+	tests := []struct {
+		name    string
+		start   entity.YearMonth
+		changes []entity.PriceChange
+		patch   entity.SubscriptionPatch
+	}{
+		{"price of started subscription", yearMonth("01-2025"), nil, entity.SubscriptionPatch{Price: &price}},
+		{"currency of started subscription", yearMonth("01-2025"), nil, entity.SubscriptionPatch{Currency: &currency}},
+		{
+			"currency with price changes",
+			entity.CurrentYearMonth().AddMonths(1),
+			[]entity.PriceChange{{EffectiveFrom: entity.CurrentYearMonth().AddMonths(3), Price: 100}},
+			entity.SubscriptionPatch{Currency: &currency},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := patchTestSubscription()
+			current.StartDate = tt.start
+			current.EndDate = nil
+
+			mockRepo := mocks.NewMockRepository(ctrl)
+			expectTx(mockRepo, entity.TxOptions{Isolation: entity.ReadCommitted})
+			mockRepo.EXPECT().LockById(ctx, current.Id).Return(current, nil).Times(1)
+			mockRepo.EXPECT().GetPriceChanges(ctx, current.Id).Return(tt.changes, nil).Times(1)
+
+			service := New(mockRepo)
+			_, err := service.PatchById(ctx, current.Id, tt.patch, 0)
+
+			assert.ErrorIs(t, err, ErrPriceRewrite)
+		})
+	}
+}
+
+func TestPatchById_Success_PriceOfFutureSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	current := patchTestSubscription()
+	current.StartDate = entity.CurrentYearMonth().AddMonths(1)
+	current.EndDate = nil
+	price := entity.Amount(29900)
+
+	patched := *current
+	patched.Price.Amount = price
+
+	// Ни один месяц подписки еще не учтен в расчетах, цену можно исправить
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTx(mockRepo, entity.TxOptions{Isolation: entity.ReadCommitted})
+	mockRepo.EXPECT().LockById(ctx, current.Id).Return(current, nil).Times(1)
+	mockRepo.EXPECT().GetPriceChanges(ctx, current.Id).Return([]entity.PriceChange{}, nil).Times(1)
+	mockRepo.EXPECT().Patch(ctx, current.Id, gomock.Any(), int64(0)).Return(&patched, nil).Times(1)
+
+	service := New(mockRepo)
+	result, err := service.PatchById(ctx, current.Id, entity.SubscriptionPatch{Price: &price}, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, price, result.Price.Amount)
+}
+
+func TestPatchById_Fail_TermExcludesPriceChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	changes := []entity.PriceChange{
+		{EffectiveFrom: yearMonth("04-2025"), Price: 100},
+		{EffectiveFrom: yearMonth("09-2025"), Price: 200},
+	}
+
+	tests := []struct {
+		name  string
+		patch entity.SubscriptionPatch
+		field string
+	}{
+		{"start_date on first change", entity.SubscriptionPatch{StartDate: yearMonthPtr("04-2025")}, "start_date"},
+		{"end_date before last change", entity.SubscriptionPatch{EndDate: yearMonthPtr("08-2025")}, "end_date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := patchTestSubscription()
+
+			mockRepo := mocks.NewMockRepository(ctrl)
+			expectTx(mockRepo, entity.TxOptions{Isolation: entity.ReadCommitted})
+			mockRepo.EXPECT().LockById(ctx, current.Id).Return(current, nil).Times(1)
+			mockRepo.EXPECT().GetPriceChanges(ctx, current.Id).Return(changes, nil).Times(1)
+
+			service := New(mockRepo)
+			_, err := service.PatchById(ctx, current.Id, tt.patch, 0)
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Fields[0].Field)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"subscriptions/internal/entity"
+)
+
+// AddPriceChange меняет цену подписки с месяца EffectiveFrom, не затрагивая расчеты за прошлые месяцы.
+// Месяц изменения должен приходиться на срок действия подписки после месяца ее начала
+func (s *subService) AddPriceChange(ctx context.Context, change *entity.PriceChange) (*entity.PriceChange, error) {
+
+	var v validator
+	v.uuid("id", change.SubscriptionId)
+	v.check(!change.EffectiveFrom.IsZero(), "effective_from", "is required")
+	v.check(change.Price >= 0, "price", "must not be negative")
+	v.check(change.Price <= maxPrice, "price", "must not exceed "+maxPrice.String())
+
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	// Сроки подписки проверяет сам запрос вставки, так что параллельный PUT или PATCH их не сдвинет.
+	// С месяца начала действует цена самой подписки
+	added, err := s.repo.AddPriceChange(ctx, change)
+	if errors.Is(err, entity.ErrOutsideTerm) {
+		return nil, &ValidationError{Fields: []FieldError{{
+			Field:   "effective_from",
+			Message: "must be after start_date and not after end_date of the subscription",
+		}}}
+	}
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	return added, nil
+}
+
+// GetPriceChanges возвращает изменения цены действующей подписки в порядке вступления в силу
+func (s *subService) GetPriceChanges(ctx context.Context, id string) ([]entity.PriceChange, error) {
+
+	var v validator
+	v.uuid("id", id)
+
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	// Проверка подписки и чтение изменений видят один снимок базы
+	var changes []entity.PriceChange
+	err := s.repo.WithTx(ctx, entity.TxOptions{Isolation: entity.RepeatableRead, ReadOnly: true}, func(ctx context.Context) error {
+		// У удаленной подписки изменения цены не показываются, как и она сама
+		if _, err := s.repo.GetById(ctx, id); err != nil {
+			return err
+		}
+
+		var err error
+		changes, err = s.repo.GetPriceChanges(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	return changes, nil
+}
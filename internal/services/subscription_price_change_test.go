@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAddPriceChange_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	subId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	change := &entity.PriceChange{SubscriptionId: subId, EffectiveFrom: yearMonth("04-2025"), Price: 39900}
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockRepo.EXPECT().AddPriceChange(ctx, change).Return(change, nil).Times(1)
+
+	service := New(mockRepo)
+	added, err := service.AddPriceChange(ctx, change)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.Amount(39900), added.Price)
+}
+
+func TestAddPriceChange_Fail_OutsideSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	subId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	change := &entity.PriceChange{SubscriptionId: subId, EffectiveFrom: yearMonth("01-2025"), Price: 100}
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockRepo.EXPECT().AddPriceChange(ctx, change).
+		Return(nil, fmt.Errorf("subscription %s: %w", subId, entity.ErrOutsideTerm)).
+		Times(1)
+
+	service := New(mockRepo)
+	_, err := service.AddPriceChange(ctx, change)
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "effective_from", validationErr.Fields[0].Field)
+}
+
+func TestAddPriceChange_Fail_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	subId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	change := &entity.PriceChange{SubscriptionId: subId, EffectiveFrom: yearMonth("04-2025"), Price: 100}
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockRepo.EXPECT().AddPriceChange(ctx, change).Return(nil, entity.ErrNotFound).Times(1)
+
+	service := New(mockRepo)
+	_, err := service.AddPriceChange(ctx, change)
+
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestAddPriceChange_Fail_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	service := New(mockRepo)
+	_, err := service.AddPriceChange(context.Background(), &entity.PriceChange{SubscriptionId: "not-a-uuid", Price: -1})
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Len(t, validationErr.Fields, 3)
+}
+
+func TestGetPriceChanges_Fail_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	subId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTx(mockRepo, entity.TxOptions{Isolation: entity.RepeatableRead, ReadOnly: true})
+	mockRepo.EXPECT().GetById(ctx, subId).Return(nil, entity.ErrNotFound).Times(1)
+
+	service := New(mockRepo)
+	_, err := service.GetPriceChanges(ctx, subId)
+
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"fmt"
+	"subscriptions/internal/entity"
+)
+
+// checkPriceHistory проверяет, что перезапись подписки current на updated не меняет цену задним числом.
+// Цену и валюту начавшейся подписки меняют только изменения цены, а валюту подписки с изменениями цены
+// не меняют вовсе: их суммы записаны в прежней валюте. Сроки подписки должны по-прежнему включать все
+// изменения цены
+func checkPriceHistory(current, updated *entity.Subscription, changes []entity.PriceChange) error {
+
+	var v validator
+	if len(changes) > 0 {
+		first, last := changes[0].EffectiveFrom, changes[len(changes)-1].EffectiveFrom
+		v.check(updated.StartDate.Before(first), "start_date", "must be before the first price change "+first.String())
+		if updated.EndDate != nil {
+			v.check(!updated.EndDate.Before(last), "end_date", "must not be before the last price change "+last.String())
+		}
+	}
+
+	if err := v.err(); err != nil {
+		return err
+	}
+
+	// Месяц начала уже учтен в расчетах, как только он наступил
+	started := !current.StartDate.After(entity.CurrentYearMonth())
+
+	if started && updated.Price.Amount != current.Price.Amount {
+		return fmt.Errorf("subscription %s: price of a started subscription: %w", current.Id, ErrPriceRewrite)
+	}
+
+	if (started || len(changes) > 0) && updated.Price.Currency != current.Price.Currency {
+		return fmt.Errorf("subscription %s: currency of a subscription with price history: %w", current.Id, ErrPriceRewrite)
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+	"subscriptions/internal/entity"
+)
+
+// RestoreById снимает мягкое удаление с подписки. Действующая подписка возвращается без изменений.
+// Ненулевая version восстанавливает подписку, только если она не менялась с этой версии
+func (s *subService) RestoreById(ctx context.Context, id string, version int64) (*entity.Subscription, error) {
+
+	var v validator
+	v.uuid("id", id)
+
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	sub, err := s.repo.Restore(ctx, id, version)
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	return sub, nil
+}
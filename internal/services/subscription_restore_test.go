@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRestoreById_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	subId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockRepo.EXPECT().Restore(ctx, subId, int64(2)).Return(&entity.Subscription{Id: subId, Version: 3}, nil).Times(1)
+
+	service := New(mockRepo)
+	sub, err := service.RestoreById(ctx, subId, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), sub.Version)
+}
+
+func TestRestoreById_Fail_InvalidId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	service := New(mockRepo)
+	_, err := service.RestoreById(context.Background(), "not-a-uuid", 0)
+
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+func TestRestoreById_Fail_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	subId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockRepo.EXPECT().Restore(ctx, subId, int64(0)).Return(nil, entity.ErrNotFound).Times(1)
+
+	service := New(mockRepo)
+	_, err := service.RestoreById(ctx, subId, 0)
+
+	assert.ErrorIs(t, err, ErrNotFound)
+}
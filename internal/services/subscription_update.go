@@ -2,20 +2,51 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"subscriptions/internal/entity"
 )
 
+// UpdateById перезаписывает подписку. Ненулевая sub.Version перезаписывает ее, только если подписка не менялась с этой версии.
+// Цену начавшейся подписки PUT не меняет, для этого есть изменения цены (ErrPriceRewrite)
 func (s *subService) UpdateById(ctx context.Context, sub *entity.Subscription) (*entity.Subscription, error) {
 
-	err := s.repo.Update(ctx, sub)
+	applySubscriptionDefaults(sub)
 
-	if err != nil {
+	var v validator
+	v.uuid("id", sub.Id)
+	v.subscription(sub)
+
+	if err := v.err(); err != nil {
 		return nil, err
 	}
 
-	subOut, err := s.repo.GetById(ctx, sub.Id)
+	// Строка подписки блокируется до записи, чтобы параллельное изменение цены не разошлось с проверкой
+	var subOut *entity.Subscription
+	err := s.repo.WithTx(ctx, entity.TxOptions{Isolation: entity.ReadCommitted}, func(ctx context.Context) error {
+		current, err := s.repo.LockById(ctx, sub.Id)
+		if err != nil {
+			return err
+		}
+
+		// Устаревшая версия важнее остальных ошибок: клиент проверял уже не ту подписку
+		if sub.Version != 0 && current.Version != sub.Version {
+			return fmt.Errorf("subscription %s: %w", sub.Id, ErrPreconditionFailed)
+		}
+
+		changes, err := s.repo.GetPriceChanges(ctx, sub.Id)
+		if err != nil {
+			return err
+		}
+
+		if err := checkPriceHistory(current, sub, changes); err != nil {
+			return err
+		}
+
+		subOut, err = s.repo.Update(ctx, sub)
+		return err
+	})
 	if err != nil {
-		return nil, err
+		return nil, mapRepoError(err)
 	}
 
 	return subOut, nil
@@ -20,15 +20,17 @@ func TestUpdateById_Success(t *testing.T) {
 	sub := &entity.Subscription{
 		Id:        "60601fee-2bf1-4721-ae6f-7636e79a0cba",
 		Name:      "Yandex Plus",
-		Price:     1500,
+		Price:     entity.Money{Amount: 1500, Currency: "RUB"},
 		UserId:    "60601fee-2bf1-4721-ae6f-7636e79a0cba",
-		StartDate: "01-2025",
-		EndDate:   "12-2025",
+		StartDate: yearMonth("01-2025"),
+		EndDate:   yearMonthPtr("12-2025"),
 	}
 
 	mockRepo := mocks.NewMockRepository(ctrl)
-	mockRepo.EXPECT().Update(ctx, sub).Return(nil).Times(1)
-	mockRepo.EXPECT().GetById(ctx, sub.Id).Return(sub, nil).Times(1)
+	expectTx(mockRepo, entity.TxOptions{Isolation: entity.ReadCommitted})
+	mockRepo.EXPECT().LockById(ctx, sub.Id).Return(sub, nil).Times(1)
+	mockRepo.EXPECT().GetPriceChanges(ctx, sub.Id).Return([]entity.PriceChange{}, nil).Times(1)
+	mockRepo.EXPECT().Update(ctx, sub).Return(sub, nil).Times(1)
 
 	service := New(mockRepo)
 	updatedSub, err := service.UpdateById(ctx, sub)
@@ -45,14 +47,17 @@ func TestUpdateById_Fail_UpdateError(t *testing.T) {
 	sub := &entity.Subscription{
 		Id:        "60601fee-2bf1-4721-ae6f-7636e79a0cba",
 		Name:      "Yandex Plus",
-		Price:     1500,
+		Price:     entity.Money{Amount: 1500, Currency: "RUB"},
 		UserId:    "60601fee-2bf1-4721-ae6f-7636e79a0cba",
-		StartDate: "01-2025",
-		EndDate:   "12-2025",
+		StartDate: yearMonth("01-2025"),
+		EndDate:   yearMonthPtr("12-2025"),
 	}
 
 	mockRepo := mocks.NewMockRepository(ctrl)
-	mockRepo.EXPECT().Update(ctx, sub).Return(fmt.Errorf("update error")).Times(1)
+	expectTx(mockRepo, entity.TxOptions{Isolation: entity.ReadCommitted})
+	mockRepo.EXPECT().LockById(ctx, sub.Id).Return(sub, nil).Times(1)
+	mockRepo.EXPECT().GetPriceChanges(ctx, sub.Id).Return([]entity.PriceChange{}, nil).Times(1)
+	mockRepo.EXPECT().Update(ctx, sub).Return(nil, fmt.Errorf("update error")).Times(1)
 
 	service := New(mockRepo)
 	updatedSub, err := service.UpdateById(ctx, sub)
@@ -62,7 +67,7 @@ func TestUpdateById_Fail_UpdateError(t *testing.T) {
 	assert.Nil(t, updatedSub)
 }
 
-func TestUpdateById_Fail_GetByIdError(t *testing.T) {
+func TestUpdateById_Fail_NotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -70,20 +75,73 @@ func TestUpdateById_Fail_GetByIdError(t *testing.T) {
 	sub := &entity.Subscription{
 		Id:        "60601fee-2bf1-4721-ae6f-7636e79a0cba",
 		Name:      "Yandex Plus",
-		Price:     1500,
+		Price:     entity.Money{Amount: 1500, Currency: "RUB"},
 		UserId:    "60601fee-2bf1-4721-ae6f-7636e79a0cba",
-		StartDate: "01-2025",
-		EndDate:   "12-2025",
+		StartDate: yearMonth("01-2025"),
+		EndDate:   yearMonthPtr("12-2025"),
 	}
 
+	// До записи дело не доходит: подписку не удалось заблокировать
 	mockRepo := mocks.NewMockRepository(ctrl)
-	mockRepo.EXPECT().Update(ctx, sub).Return(nil).Times(1)
-	mockRepo.EXPECT().GetById(ctx, sub.Id).Return(nil, fmt.Errorf("subscription not found")).Times(1)
+	expectTx(mockRepo, entity.TxOptions{Isolation: entity.ReadCommitted})
+	mockRepo.EXPECT().LockById(ctx, sub.Id).Return(nil, fmt.Errorf("subscription %s: %w", sub.Id, entity.ErrNotFound)).Times(1)
 
 	service := New(mockRepo)
 	updatedSub, err := service.UpdateById(ctx, sub)
 
-	assert.Error(t, err)
-	assert.Equal(t, "subscription not found", err.Error())
+	assert.ErrorIs(t, err, ErrNotFound)
 	assert.Nil(t, updatedSub)
 }
+
+func TestUpdateById_Fail_PriceRewrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	current := &entity.Subscription{
+		Id:        "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+		Name:      "Yandex Plus",
+		Price:     entity.Money{Amount: 1500, Currency: "RUB"},
+		UserId:    "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+		StartDate: yearMonth("01-2025"),
+	}
+	sub := *current
+	sub.Price.Amount = 1900
+
+	// Новая цена подписки, начавшейся в прошлом, переписала бы уже посчитанные месяцы
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTx(mockRepo, entity.TxOptions{Isolation: entity.ReadCommitted})
+	mockRepo.EXPECT().LockById(ctx, sub.Id).Return(current, nil).Times(1)
+	mockRepo.EXPECT().GetPriceChanges(ctx, sub.Id).Return([]entity.PriceChange{}, nil).Times(1)
+
+	service := New(mockRepo)
+	_, err := service.UpdateById(ctx, &sub)
+
+	assert.ErrorIs(t, err, ErrPriceRewrite)
+}
+
+func TestUpdateById_Fail_VersionMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	current := &entity.Subscription{
+		Id:        "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+		Name:      "Yandex Plus",
+		Price:     entity.Money{Amount: 1500, Currency: "RUB"},
+		UserId:    "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+		StartDate: yearMonth("01-2025"),
+		Version:   3,
+	}
+	sub := *current
+	sub.Version = 2
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTx(mockRepo, entity.TxOptions{Isolation: entity.ReadCommitted})
+	mockRepo.EXPECT().LockById(ctx, sub.Id).Return(current, nil).Times(1)
+
+	service := New(mockRepo)
+	_, err := service.UpdateById(ctx, &sub)
+
+	assert.ErrorIs(t, err, ErrPreconditionFailed)
+}
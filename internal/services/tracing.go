@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"subscriptions/internal/entity"
+	"subscriptions/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingService — декоратор Service, который открывает спан на каждый вызов метода
+type tracingService struct {
+	next Service
+}
+
+// WithTracing оборачивает сервис трейсингом, спаны вкладываются в спан обработчика из контекста
+func WithTracing(next Service) Service {
+	return &tracingService{next: next}
+}
+
+func (s *tracingService) start(ctx context.Context, method string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "Service."+method)
+}
+
+// finish вызывается через defer, поэтому получает ошибку по указателю уже после возврата из метода.
+// Ошибки валидации, отсутствие объекта и устаревшая версия — штатные ответы, спан ими не помечается как ошибочный
+func finish(span trace.Span, err *error) {
+	defer span.End()
+
+	if *err == nil {
+		return
+	}
+
+	span.RecordError(*err)
+	span.SetAttributes(attribute.String("service.result", callResult(*err)))
+
+	if !errors.Is(*err, ErrValidation) && !errors.Is(*err, ErrNotFound) && !errors.Is(*err, ErrPreconditionFailed) &&
+		!errors.Is(*err, ErrPriceRewrite) {
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+}
+
+func (s *tracingService) Create(ctx context.Context, sub *entity.Subscription) (_ *entity.Subscription, err error) {
+	ctx, span := s.start(ctx, "Create")
+	defer finish(span, &err)
+	return s.next.Create(ctx, sub)
+}
+
+func (s *tracingService) GetById(ctx context.Context, id string) (_ *entity.Subscription, err error) {
+	ctx, span := s.start(ctx, "GetById")
+	defer finish(span, &err)
+	return s.next.GetById(ctx, id)
+}
+
+func (s *tracingService) UpdateById(ctx context.Context, sub *entity.Subscription) (_ *entity.Subscription, err error) {
+	ctx, span := s.start(ctx, "UpdateById")
+	defer finish(span, &err)
+	return s.next.UpdateById(ctx, sub)
+}
+
+func (s *tracingService) PatchById(ctx context.Context, id string, patch entity.SubscriptionPatch, version int64) (_ *entity.Subscription, err error) {
+	ctx, span := s.start(ctx, "PatchById")
+	defer finish(span, &err)
+	return s.next.PatchById(ctx, id, patch, version)
+}
+
+func (s *tracingService) DeleteById(ctx context.Context, id string, version int64) (err error) {
+	ctx, span := s.start(ctx, "DeleteById")
+	defer finish(span, &err)
+	return s.next.DeleteById(ctx, id, version)
+}
+
+func (s *tracingService) RestoreById(ctx context.Context, id string, version int64) (_ *entity.Subscription, err error) {
+	ctx, span := s.start(ctx, "RestoreById")
+	defer finish(span, &err)
+	return s.next.RestoreById(ctx, id, version)
+}
+
+func (s *tracingService) GetHistory(ctx context.Context, id string, pagination entity.Pagination) (_ *entity.SubscriptionHistory, err error) {
+	ctx, span := s.start(ctx, "GetHistory")
+	defer finish(span, &err)
+	return s.next.GetHistory(ctx, id, pagination)
+}
+
+func (s *tracingService) AddPriceChange(ctx context.Context, change *entity.PriceChange) (_ *entity.PriceChange, err error) {
+	ctx, span := s.start(ctx, "AddPriceChange")
+	defer finish(span, &err)
+	return s.next.AddPriceChange(ctx, change)
+}
+
+func (s *tracingService) GetPriceChanges(ctx context.Context, id string) (_ []entity.PriceChange, err error) {
+	ctx, span := s.start(ctx, "GetPriceChanges")
+	defer finish(span, &err)
+	return s.next.GetPriceChanges(ctx, id)
+}
+
+func (s *tracingService) GetList(ctx context.Context, filter entity.SubscriptionFilter, sort entity.Sort, pagination entity.Pagination) (_ *entity.SubscriptionList, err error) {
+	ctx, span := s.start(ctx, "GetList")
+	defer finish(span, &err)
+	return s.next.GetList(ctx, filter, sort, pagination)
+}
+
+func (s *tracingService) GetSummary(ctx context.Context, userId string, serviceName string, startDate entity.YearMonth, endDate *entity.YearMonth, currency string) (_ *entity.Summary, err error) {
+	ctx, span := s.start(ctx, "GetSummary")
+	defer finish(span, &err)
+	return s.next.GetSummary(ctx, userId, serviceName, startDate, endDate, currency)
+}
+
+func (s *tracingService) GetUserSummary(ctx context.Context, userId string, serviceName string, startDate entity.YearMonth, endDate *entity.YearMonth, currency string) (_ *entity.UserSummary, err error) {
+	ctx, span := s.start(ctx, "GetUserSummary")
+	defer finish(span, &err)
+	return s.next.GetUserSummary(ctx, userId, serviceName, startDate, endDate, currency)
+}
+
+func (s *tracingService) GetMonthlyBreakdown(ctx context.Context, userId string, startDate entity.YearMonth, endDate *entity.YearMonth, currency string) (_ []entity.MonthlyCost, err error) {
+	ctx, span := s.start(ctx, "GetMonthlyBreakdown")
+	defer finish(span, &err)
+	return s.next.GetMonthlyBreakdown(ctx, userId, startDate, endDate, currency)
+}
+
+func (s *tracingService) UpsertExchangeRate(ctx context.Context, rate *entity.ExchangeRate) (_ *entity.ExchangeRate, err error) {
+	ctx, span := s.start(ctx, "UpsertExchangeRate")
+	defer finish(span, &err)
+	return s.next.UpsertExchangeRate(ctx, rate)
+}
+
+func (s *tracingService) GetExchangeRates(ctx context.Context, currency string) (_ []entity.ExchangeRate, err error) {
+	ctx, span := s.start(ctx, "GetExchangeRates")
+	defer finish(span, &err)
+	return s.next.GetExchangeRates(ctx, currency)
+}
+
+func (s *tracingService) DeleteExchangeRate(ctx context.Context, currency string, validFrom entity.YearMonth) (err error) {
+	ctx, span := s.start(ctx, "DeleteExchangeRate")
+	defer finish(span, &err)
+	return s.next.DeleteExchangeRate(ctx, currency, validFrom)
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/repositories/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWithTracing_SpanPerCall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx := context.Background()
+	subId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockRepo.EXPECT().GetById(gomock.Any(), subId).Return(&entity.Subscription{Id: subId}, nil)
+	mockRepo.EXPECT().GetById(gomock.Any(), subId).Return(nil, entity.ErrNotFound)
+	mockRepo.EXPECT().GetById(gomock.Any(), subId).Return(nil, errors.New("connection refused"))
+
+	service := WithTracing(New(mockRepo))
+
+	_, err := service.GetById(ctx, subId)
+	assert.NoError(t, err)
+
+	_, err = service.GetById(ctx, subId)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = service.GetById(ctx, subId)
+	assert.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	for _, span := range spans {
+		assert.Equal(t, "Service.GetById", span.Name())
+	}
+
+	// Отсутствие подписки не считается сбоем, ошибка репозитория — считается
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	assert.Len(t, spans[1].Events(), 1)
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+}
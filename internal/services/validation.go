@@ -0,0 +1,157 @@
+package services
+
+import (
+	"strings"
+	"subscriptions/internal/entity"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxNameLength = 100
+	// Верхняя граница цены в минимальных единицах: 10 000 000.00
+	maxPrice           entity.Amount = 1_000_000_000
+	maxBillingInterval               = 100
+	maxListLimit                     = 100
+)
+
+// Наибольший курс, который помещается в колонку NUMERIC(18, 6). Наименьший положительный курс,
+// 0.000001, задан самим типом entity.Rate
+const maxExchangeRate entity.Rate = 999_999_999_999_999_999
+
+// Кроме букв, цифр и пробела в названии сервиса допускаются эти символы
+const nameExtraChars = " .,:;!?&+-_'\"()/#@"
+
+// validator накапливает ошибки по полям, чтобы вернуть клиенту их все сразу
+type validator struct {
+	fields []FieldError
+}
+
+func (v *validator) check(ok bool, field, message string) {
+	if !ok {
+		v.fields = append(v.fields, FieldError{Field: field, Message: message})
+	}
+}
+
+func (v *validator) uuid(field, value string) {
+	if value == "" {
+		v.check(false, field, "is required")
+		return
+	}
+
+	_, err := uuid.Parse(value)
+	v.check(err == nil, field, "must be a valid UUID")
+}
+
+func (v *validator) name(field, value string) {
+	switch {
+	case value == "":
+		v.check(false, field, "is required")
+	case !utf8.ValidString(value):
+		v.check(false, field, "must be valid UTF-8")
+	case utf8.RuneCountInString(value) > maxNameLength:
+		v.check(false, field, "must be at most 100 characters")
+	case strings.TrimSpace(value) != value:
+		v.check(false, field, "must not start or end with whitespace")
+	default:
+		v.check(strings.IndexFunc(value, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune(nameExtraChars, r)
+		}) < 0, field, "contains unsupported characters")
+	}
+}
+
+func (v *validator) currency(field, value string) {
+	v.check(entity.IsValidCurrency(value), field, "must be an ISO 4217 code")
+}
+
+func (v *validator) period(startDate entity.YearMonth, endDate *entity.YearMonth) {
+	v.check(!startDate.IsZero(), "start_date", "is required")
+
+	if endDate != nil && !startDate.IsZero() {
+		v.check(!endDate.Before(startDate), "end_date", "must not be before start_date")
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Fields: v.fields}
+}
+
+// normalizeCurrency приводит код валюты к верхнему регистру, пустая валюта считается рублями
+func normalizeCurrency(currency string) string {
+	if currency == "" {
+		return entity.BaseCurrency
+	}
+
+	return strings.ToUpper(currency)
+}
+
+// applySubscriptionDefaults подставляет значения по умолчанию для необязательных полей подписки
+func applySubscriptionDefaults(sub *entity.Subscription) {
+	// Без billing_period подписка считается ежемесячной
+	if sub.BillingPeriod == "" {
+		sub.BillingPeriod = entity.BillingPeriodMonth
+	}
+
+	if sub.BillingInterval == 0 {
+		sub.BillingInterval = 1
+	}
+
+	sub.Price.Currency = normalizeCurrency(sub.Price.Currency)
+}
+
+func (v *validator) subscription(sub *entity.Subscription) {
+	v.name("service_name", sub.Name)
+	v.check(sub.Price.Amount >= 0, "price", "must not be negative")
+	v.check(sub.Price.Amount <= maxPrice, "price", "must not exceed "+maxPrice.String())
+	v.currency("currency", sub.Price.Currency)
+	v.uuid("user_id", sub.UserId)
+	v.period(sub.StartDate, sub.EndDate)
+	v.check(sub.BillingPeriod.IsValid(), "billing_period", "must be one of week, month, quarter, year")
+	v.check(sub.BillingInterval >= 1 && sub.BillingInterval <= maxBillingInterval,
+		"billing_interval", "must be between 1 and 100")
+}
+
+// summaryQuery проверяет параметры расчета итогов: пользователя, период и валюту.
+// Без end_date период считается до текущего месяца, поэтому начинаться позже него он не может.
+// В period это не проверяется: подписка может начинаться в будущем и не иметь даты окончания
+func (v *validator) summaryQuery(userId string, startDate entity.YearMonth, endDate *entity.YearMonth, currency string) {
+	v.uuid("user_id", userId)
+	v.period(startDate, endDate)
+	if endDate == nil {
+		v.check(!startDate.After(entity.CurrentYearMonth()), "start_date", "must not be after the current month when end_date is omitted")
+	}
+	v.currency("currency", currency)
+}
+
+// exchangeRateKey проверяет валюту и месяц, которыми адресуется курс
+func (v *validator) exchangeRateKey(currency string, validFrom entity.YearMonth) {
+	v.check(entity.IsValidCurrency(currency) && currency != entity.BaseCurrency,
+		"currency", "must be an ISO 4217 code other than "+entity.BaseCurrency)
+	v.check(!validFrom.IsZero(), "valid_from", "is required")
+}
+
+// listFilter проверяет условия отбора списка подписок
+func (v *validator) listFilter(filter entity.SubscriptionFilter) {
+	if filter.UserId != "" {
+		v.uuid("user_id", filter.UserId)
+	}
+
+	v.check(utf8.RuneCountInString(filter.ServiceNamePrefix) <= maxNameLength,
+		"service_name_prefix", "must be at most 100 characters")
+
+	if filter.PriceMin != nil && filter.PriceMax != nil {
+		v.check(*filter.PriceMin <= *filter.PriceMax, "price_max", "must not be less than price_min")
+	}
+
+	if filter.StartFrom != nil && filter.StartTo != nil {
+		v.check(!filter.StartTo.Before(*filter.StartFrom), "start_to", "must not be before start_from")
+	}
+
+	v.check(filter.Status == "" || filter.Status.IsValid(), "status", "must be active or ended")
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"subscriptions/internal/entity"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validSubscription() *entity.Subscription {
+	return &entity.Subscription{
+		Name:      "Yandex Plus",
+		Price:     entity.Money{Amount: 39900, Currency: "rub"},
+		UserId:    "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+		StartDate: yearMonth("01-2025"),
+		EndDate:   yearMonthPtr("12-2025"),
+	}
+}
+
+func TestApplySubscriptionDefaults(t *testing.T) {
+	sub := validSubscription()
+	sub.Price.Currency = ""
+
+	applySubscriptionDefaults(sub)
+
+	assert.Equal(t, entity.BillingPeriodMonth, sub.BillingPeriod)
+	assert.Equal(t, 1, sub.BillingInterval)
+	assert.Equal(t, entity.BaseCurrency, sub.Price.Currency)
+}
+
+func TestValidator_Subscription(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(sub *entity.Subscription)
+		field  string
+	}{
+		{"valid", func(sub *entity.Subscription) {}, ""},
+		{"empty name", func(sub *entity.Subscription) { sub.Name = "" }, "service_name"},
+		{"long name", func(sub *entity.Subscription) { sub.Name = strings.Repeat("a", maxNameLength+1) }, "service_name"},
+		{"padded name", func(sub *entity.Subscription) { sub.Name = " Yandex Plus" }, "service_name"},
+		{"control char in name", func(sub *entity.Subscription) { sub.Name = "Yandex\tPlus" }, "service_name"},
+		{"negative price", func(sub *entity.Subscription) { sub.Price.Amount = -1 }, "price"},
+		{"price too high", func(sub *entity.Subscription) { sub.Price.Amount = maxPrice + 1 }, "price"},
+		{"invalid currency", func(sub *entity.Subscription) { sub.Price.Currency = "RUBL" }, "currency"},
+		{"invalid user id", func(sub *entity.Subscription) { sub.UserId = "user-123" }, "user_id"},
+		{"missing start date", func(sub *entity.Subscription) { sub.StartDate = entity.YearMonth{} }, "start_date"},
+		{"end before start", func(sub *entity.Subscription) { sub.EndDate = yearMonthPtr("12-2024") }, "end_date"},
+		{"invalid billing period", func(sub *entity.Subscription) { sub.BillingPeriod = "day" }, "billing_period"},
+		{"billing interval too high", func(sub *entity.Subscription) { sub.BillingInterval = maxBillingInterval + 1 }, "billing_interval"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := validSubscription()
+			applySubscriptionDefaults(sub)
+			tt.modify(sub)
+
+			var v validator
+			v.subscription(sub)
+			err := v.err()
+
+			if tt.field == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Len(t, validationErr.Fields, 1)
+			assert.Equal(t, tt.field, validationErr.Fields[0].Field)
+		})
+	}
+}
+
+func TestValidator_CollectsAllFields(t *testing.T) {
+	var v validator
+	v.subscription(&entity.Subscription{})
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, v.err(), &validationErr)
+
+	fields := make([]string, 0, len(validationErr.Fields))
+	for _, f := range validationErr.Fields {
+		fields = append(fields, f.Field)
+	}
+
+	assert.Equal(t, []string{"service_name", "currency", "user_id", "start_date", "billing_period", "billing_interval"}, fields)
+	assert.Contains(t, validationErr.Error(), "user_id: is required")
+}
+
+func TestValidator_SummaryQuery_FutureStartWithoutEndDate(t *testing.T) {
+	userId := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	nextMonth := entity.CurrentYearMonth().AddMonths(1)
+
+	var v validator
+	v.summaryQuery(userId, nextMonth, nil, "RUB")
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, v.err(), &validationErr)
+	assert.Equal(t, "start_date", validationErr.Fields[0].Field)
+
+	// С явным end_date будущий период допустим, как и период с началом в текущем месяце
+	v = validator{}
+	v.summaryQuery(userId, nextMonth, &nextMonth, "RUB")
+	v.summaryQuery(userId, entity.CurrentYearMonth(), nil, "RUB")
+	assert.NoError(t, v.err())
+}
+
+func TestMapRepoError(t *testing.T) {
+	assert.NoError(t, mapRepoError(nil))
+
+	notFound := mapRepoError(fmt.Errorf("subscription 1: %w", entity.ErrNotFound))
+	assert.ErrorIs(t, notFound, ErrNotFound)
+
+	noRows := mapRepoError(pgx.ErrNoRows)
+	assert.ErrorIs(t, noRows, ErrNotFound)
+	assert.ErrorIs(t, noRows, pgx.ErrNoRows)
+
+	conflict := mapRepoError(fmt.Errorf("failed to create subscription: %w", &pgconn.PgError{Code: pgUniqueViolation}))
+	assert.ErrorIs(t, conflict, ErrConflict)
+
+	other := fmt.Errorf("connection refused")
+	assert.Equal(t, other, mapRepoError(other))
+}
@@ -0,0 +1,20 @@
+package exchangerate
+
+import "subscriptions/internal/entity"
+
+// RateRequest represents exchange rate upsert request
+type RateRequest struct {
+	Rate entity.Rate `json:"rate" swaggertype:"number" example:"92.5" binding:"required,gt=0"`
+}
+
+// RateResponse represents exchange rate response: price of one unit of currency in RUB from valid_from month
+type RateResponse struct {
+	Currency  string           `json:"currency" example:"USD"`
+	ValidFrom entity.YearMonth `json:"valid_from" swaggertype:"string" example:"01-2025"`
+	Rate      entity.Rate      `json:"rate" swaggertype:"number" example:"92.5"`
+}
+
+// ListResponse represents exchange rates list response
+type ListResponse struct {
+	Rates []RateResponse `json:"rates"`
+}
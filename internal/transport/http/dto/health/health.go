@@ -0,0 +1,26 @@
+package health
+
+// Response represents liveness or readiness status
+type Response struct {
+	Status    string     `json:"status" example:"ready"`
+	Error     string     `json:"error,omitempty" example:"database is unavailable"`
+	Migration *Migration `json:"migration,omitempty"`
+	Pool      *Pool      `json:"pool,omitempty"`
+}
+
+// Migration represents applied schema version from golang-migrate
+type Migration struct {
+	Version uint `json:"version" example:"5"`
+	Dirty   bool `json:"dirty" example:"false"`
+}
+
+// Pool represents database connection pool statistics
+type Pool struct {
+	TotalConns        int32 `json:"total_conns" example:"5"`
+	IdleConns         int32 `json:"idle_conns" example:"4"`
+	AcquiredConns     int32 `json:"acquired_conns" example:"1"`
+	ConstructingConns int32 `json:"constructing_conns" example:"0"`
+	MaxConns          int32 `json:"max_conns" example:"10"`
+	AcquireCount      int64 `json:"acquire_count" example:"1024"`
+	EmptyAcquireCount int64 `json:"empty_acquire_count" example:"3"`
+}
@@ -0,0 +1,21 @@
+package problem
+
+// ContentType is the media type of error responses (RFC 7807)
+const ContentType = "application/problem+json"
+
+// Problem represents error response in RFC 7807 problem details format
+type Problem struct {
+	Type          string         `json:"type" example:"/problems/validation-error"`
+	Title         string         `json:"title" example:"Validation failed"`
+	Status        int            `json:"status" example:"400"`
+	Detail        string         `json:"detail,omitempty" example:"One or more parameters are invalid"`
+	Instance      string         `json:"instance,omitempty" example:"/api/subscriptions/"`
+	RequestId     string         `json:"request_id,omitempty" example:"4f1c2a9e-8d0b-4e55-9a7a-2f3c1d5e6b7a"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// InvalidParam describes why a single request parameter was rejected
+type InvalidParam struct {
+	Name   string `json:"name" example:"end_date"`
+	Reason string `json:"reason" example:"must not be before start_date"`
+}
@@ -1,43 +1,181 @@
 package subscription
 
-// SubRequest represents subscription creation request
+import (
+	"subscriptions/internal/entity"
+	"time"
+)
+
+// SubRequest represents subscription creation request.
+// Price is accepted both as a decimal string ("199.99") and as a number (199.99), up to two fractional digits
 type SubRequest struct {
-	Name      string `json:"service_name" example:"Yandex Plus" binding:"required"`
-	Price     int    `json:"price" example:"400" binding:"required,min=0"`
-	UserId    string `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba" binding:"required"`
-	StartDate string `json:"start_date" example:"07-2025" binding:"required"`
-	EndDate   string `json:"end_date,omitempty" example:"12-2025"`
+	Name            string            `json:"service_name" example:"Yandex Plus" binding:"required"`
+	Price           entity.Amount     `json:"price" swaggertype:"string" example:"199.99" binding:"required"`
+	UserId          string            `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba" binding:"required"`
+	StartDate       entity.YearMonth  `json:"start_date" swaggertype:"string" example:"07-2025" binding:"required"`
+	EndDate         *entity.YearMonth `json:"end_date,omitempty" swaggertype:"string" example:"12-2025"`
+	BillingPeriod   string            `json:"billing_period,omitempty" example:"month" enums:"week,month,quarter,year" default:"month"`
+	BillingInterval int               `json:"billing_interval,omitempty" example:"1" minimum:"1" default:"1"`
+	Currency        string            `json:"currency,omitempty" example:"RUB" default:"RUB"`
+}
+
+// PatchRequest represents a JSON Merge Patch (RFC 7396) of a subscription.
+// Only fields present in the document are changed; end_date set to null makes the subscription open-ended
+type PatchRequest struct {
+	Name            *string           `json:"service_name,omitempty" example:"Yandex Plus"`
+	Price           *entity.Amount    `json:"price,omitempty" swaggertype:"string" example:"299.99"`
+	UserId          *string           `json:"user_id,omitempty" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	StartDate       *entity.YearMonth `json:"start_date,omitempty" swaggertype:"string" example:"07-2025"`
+	EndDate         *entity.YearMonth `json:"end_date,omitempty" swaggertype:"string" example:"12-2025" extensions:"x-nullable"`
+	BillingPeriod   *string           `json:"billing_period,omitempty" example:"month" enums:"week,month,quarter,year"`
+	BillingInterval *int              `json:"billing_interval,omitempty" example:"1" minimum:"1"`
+	Currency        *string           `json:"currency,omitempty" example:"RUB"`
 }
 
 // SubResponse represents subscription response
 type SubResponse struct {
-	Id        string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Name      string `json:"service_name" example:"Yandex Plus"`
-	Price     int    `json:"price" example:"400"`
-	UserId    string `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
-	StartDate string `json:"start_date" example:"07-2025"`
-	EndDate   string `json:"end_date,omitempty" example:"12-2025"`
+	Id              string            `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name            string            `json:"service_name" example:"Yandex Plus"`
+	Price           entity.Amount     `json:"price" swaggertype:"string" example:"199.99"`
+	UserId          string            `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	StartDate       entity.YearMonth  `json:"start_date" swaggertype:"string" example:"07-2025"`
+	EndDate         *entity.YearMonth `json:"end_date,omitempty" swaggertype:"string" example:"12-2025"`
+	BillingPeriod   string            `json:"billing_period" example:"month"`
+	BillingInterval int               `json:"billing_interval" example:"1"`
+	Currency        string            `json:"currency" example:"RUB"`
+	// Version changes on every modification; the same value is returned in the ETag header
+	Version   int64     `json:"version" example:"1"`
+	CreatedAt time.Time `json:"created_at" example:"2025-07-01T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2025-07-15T09:30:00Z"`
+	// Principals from the gateway header; omitted when the change was made by an anonymous request
+	CreatedBy *string `json:"created_by,omitempty" example:"alice@example.com"`
+	UpdatedBy *string `json:"updated_by,omitempty" example:"bob@example.com"`
+	// Set only for soft-deleted subscriptions, which are returned by the list with include_deleted=true
+	DeletedAt *time.Time `json:"deleted_at,omitempty" example:"2025-08-01T10:00:00Z"`
 }
 
 // Summary represents subscription summary response
 type Summary struct {
-	UserId      string `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
-	ServiceName string `json:"service_name" example:"Yandex Plus"`
-	StartDate   string `json:"start_date" example:"01-2025"`
-	EndDate     string `json:"end_date,omitempty" example:"12-2025"`
-	TotalCost   int    `json:"total_cost" example:"800"`
+	UserId        string            `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	ServiceName   string            `json:"service_name" example:"Yandex Plus"`
+	StartDate     entity.YearMonth  `json:"start_date" swaggertype:"string" example:"01-2025"`
+	EndDate       *entity.YearMonth `json:"end_date,omitempty" swaggertype:"string" example:"12-2025"`
+	Currency      string            `json:"currency" example:"RUB"`
+	TotalCost     entity.Amount     `json:"total_cost" swaggertype:"string" example:"4800.00"`
+	Months        int               `json:"months" example:"12"`
+	Subscriptions []SummaryItem     `json:"subscriptions"`
+}
+
+// SummaryItem represents the contribution of a single subscription to the summary
+type SummaryItem struct {
+	Id              string            `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Price           entity.Amount     `json:"price" swaggertype:"string" example:"199.99"`
+	Currency        string            `json:"currency" example:"RUB"`
+	StartDate       entity.YearMonth  `json:"start_date" swaggertype:"string" example:"01-2025"`
+	EndDate         *entity.YearMonth `json:"end_date,omitempty" swaggertype:"string" example:"12-2025"`
+	BillingPeriod   string            `json:"billing_period" example:"month"`
+	BillingInterval int               `json:"billing_interval" example:"1"`
+	Months          int               `json:"months" example:"12"`
+	Charges         int               `json:"charges" example:"12"`
+	Cost            entity.Amount     `json:"cost" swaggertype:"string" example:"4800.00"`
+}
+
+// UserSummary represents user-wide summary response grouped by service
+type UserSummary struct {
+	UserId      string            `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	ServiceName string            `json:"service_name,omitempty" example:"Yandex Plus"`
+	StartDate   entity.YearMonth  `json:"start_date" swaggertype:"string" example:"01-2025"`
+	EndDate     *entity.YearMonth `json:"end_date,omitempty" swaggertype:"string" example:"12-2025"`
+	Currency    string            `json:"currency" example:"RUB"`
+	TotalCost   entity.Amount     `json:"total_cost" swaggertype:"string" example:"8400.00"`
+	Months      int               `json:"months" example:"24"`
+	Services    []ServiceSummary  `json:"services"`
+}
+
+// ServiceSummary represents totals for a single service
+type ServiceSummary struct {
+	ServiceName   string        `json:"service_name" example:"Yandex Plus"`
+	TotalCost     entity.Amount `json:"total_cost" swaggertype:"string" example:"4800.00"`
+	Months        int           `json:"months" example:"12"`
+	Subscriptions []SummaryItem `json:"subscriptions"`
+}
+
+// MonthlyBreakdown represents per-month cost breakdown response
+type MonthlyBreakdown struct {
+	UserId    string           `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	StartDate entity.YearMonth `json:"start_date" swaggertype:"string" example:"01-2025"`
+	EndDate   entity.YearMonth `json:"end_date" swaggertype:"string" example:"12-2025"`
+	Currency  string           `json:"currency" example:"RUB"`
+	TotalCost entity.Amount    `json:"total_cost" swaggertype:"string" example:"8400.00"`
+	Months    []MonthlyCost    `json:"months"`
 }
 
-// ErrorResponse represents error response
-type ErrorResponse struct {
-	Message string `json:"message,omitempty" example:"string"`
+// MonthlyCost represents total spend for a single calendar month
+type MonthlyCost struct {
+	Month         entity.YearMonth  `json:"month" swaggertype:"string" example:"01-2025"`
+	TotalCost     entity.Amount     `json:"total_cost" swaggertype:"string" example:"700.00"`
+	Subscriptions []MonthlyCostItem `json:"subscriptions"`
 }
 
-// ListResponse represents paginated list response
+// MonthlyCostItem represents a subscription contributing to the month total
+type MonthlyCostItem struct {
+	Id          string        `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ServiceName string        `json:"service_name" example:"Yandex Plus"`
+	Charges     int           `json:"charges" example:"1"`
+	Cost        entity.Amount `json:"cost" swaggertype:"string" example:"400.00"`
+}
+
+// ListResponse represents paginated list response.
+// Page is returned only in legacy page/limit mode; NextCursor is empty on the last page;
+// TotalCount is returned only when requested with with_total=true
 type ListResponse struct {
-    Page         int            `json:"page" example:"1"`
-    Limit        int            `json:"limit" example:"20"`
-    HasNext      bool           `json:"has_next" example:"true"`
-    Subscriptions []SubResponse `json:"subscriptions"`
+	Page          int           `json:"page,omitempty" example:"1"`
+	Limit         int           `json:"limit" example:"20"`
+	HasNext       bool          `json:"has_next" example:"true"`
+	TotalCount    *int          `json:"total_count,omitempty" example:"42"`
+	NextCursor    string        `json:"next_cursor,omitempty" example:"eyJrIjoiaWQiLCJvIjoiYXNjIiwiaWQiOiI1NTBlODQwMC1lMjliLTQxZDQtYTcxNi00NDY2NTU0NDAwMDAifQ"`
+	Subscriptions []SubResponse `json:"subscriptions"`
+}
+
+// EventResponse represents a single entry of the subscription change history.
+// Before is omitted for "created" events, After is omitted for "purged" events.
+// "price_changed" events have neither and carry PriceChange instead
+type EventResponse struct {
+	Id          int64                `json:"id" example:"42"`
+	Type        string               `json:"type" example:"updated" enums:"created,updated,deleted,restored,purged,price_changed"`
+	Actor       *string              `json:"actor,omitempty" example:"bob@example.com"`
+	OccurredAt  time.Time            `json:"occurred_at" example:"2025-07-15T09:30:00Z"`
+	Before      *SubResponse         `json:"before,omitempty"`
+	After       *SubResponse         `json:"after,omitempty"`
+	PriceChange *PriceChangeResponse `json:"price_change,omitempty"`
+}
+
+// HistoryResponse represents a page of the subscription change history, newest events first.
+// NextCursor is empty on the last page
+type HistoryResponse struct {
+	Limit      int             `json:"limit" example:"20"`
+	HasNext    bool            `json:"has_next" example:"true"`
+	NextCursor string          `json:"next_cursor,omitempty" example:"eyJrIjoiZXZlbnRfaWQiLCJvIjoiZGVzYyIsImlkIjoiNDIifQ"`
+	Events     []EventResponse `json:"events"`
 }
 
+// PriceChangeRequest represents a new price of the subscription in force from effective_from month.
+// The price is in the currency of the subscription
+type PriceChangeRequest struct {
+	EffectiveFrom entity.YearMonth `json:"effective_from" swaggertype:"string" example:"04-2025" binding:"required"`
+	Price         entity.Amount    `json:"price" swaggertype:"string" example:"399.00" binding:"required"`
+}
+
+// PriceChangeResponse represents a price change of the subscription
+type PriceChangeResponse struct {
+	SubscriptionId string           `json:"subscription_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	EffectiveFrom  entity.YearMonth `json:"effective_from" swaggertype:"string" example:"04-2025"`
+	Price          entity.Amount    `json:"price" swaggertype:"string" example:"399.00"`
+	CreatedAt      time.Time        `json:"created_at" example:"2025-03-20T10:00:00Z"`
+	CreatedBy      *string          `json:"created_by,omitempty" example:"alice@example.com"`
+}
+
+// PriceChangesResponse represents price changes of the subscription ordered by effective_from.
+// The price of the subscription itself is in force from its start_date until the first change
+type PriceChangesResponse struct {
+	PriceChanges []PriceChangeResponse `json:"price_changes"`
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// etag строит сильный ETag из версии подписки
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ifMatchVersion возвращает версию из If-Match для условной записи. 0 — заголовка нет или передан "*",
+// тогда запись безусловная. If-Match сравнивается строго, поэтому слабый или чужой тег не совпадает
+// ни с одной версией: для него возвращается -1 и запись завершится 412
+func ifMatchVersion(r *http.Request) (int64, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	// Версия у подписки одна, список тегов сравнить с ней за один условный запрос нельзя
+	if strings.Contains(header, ",") {
+		return 0, invalidField("If-Match", "must contain a single entity tag")
+	}
+
+	value, ok := strings.CutPrefix(header, `"`)
+	if !ok {
+		return -1, nil
+	}
+	value, ok = strings.CutSuffix(value, `"`)
+	if !ok {
+		return -1, nil
+	}
+
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || version <= 0 {
+		return -1, nil
+	}
+
+	return version, nil
+}
+
+// noneMatch сообщает, что ETag совпал с одним из тегов If-None-Match и клиенту можно ответить 304.
+// If-None-Match сравнивается слабо: префикс W/ не учитывается
+func noneMatch(r *http.Request, tag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == tag {
+			return true
+		}
+	}
+
+	return false
+}
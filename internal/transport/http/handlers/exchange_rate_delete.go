@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"subscriptions/internal/entity"
+	"subscriptions/pkg/logger"
+
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// DeleteExchangeRate removes exchange rate
+// @Summary Удаление курса валюты, действующего с указанного месяца
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Param currency path string true "Currency code (ISO 4217)" default(USD)
+// @Param valid_from path string true "Month from which the rate is effective in MM-YYYY format" default(01-2025)
+// @Success 204 "Exchange rate deleted successfully"
+// @Failure 400 {object} problem.Problem "Invalid currency or valid_from"
+// @Failure 404 {object} problem.Problem "Exchange rate not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /api/admin/exchange-rates/{currency}/{valid_from} [delete]
+func (h *Handlers) DeleteExchangeRate(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "DeleteExchangeRate")
+	defer span.End()
+
+	ctx := r.Context()
+
+	currency := chi.URLParam(r, "currency")
+	validFromStr := chi.URLParam(r, "valid_from")
+
+	validFrom, err := entity.ParseYearMonth(validFromStr)
+	if err != nil {
+		errStr := h.sendServiceError(w, r, invalidField("valid_from", "must be in MM-YYYY format"), "", "")
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.String("valid_from", validFromStr),
+			zap.Error(err))
+		return
+	}
+
+	err = h.service.DeleteExchangeRate(ctx, currency, validFrom)
+
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "Exchange rate not found", "Couldn't delete exchange rate")
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.String("currency", currency),
+			zap.Stringer("valid_from", validFrom),
+			zap.Error(err))
+		return
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"Exchange rate deleted successfully",
+		zap.String("currency", currency),
+		zap.Stringer("valid_from", validFrom))
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"subscriptions/internal/transport/http/dto/exchangerate"
+	"subscriptions/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// GetExchangeRates returns exchange rates
+// @Summary Получение списка курсов валют
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Param currency query string false "Фильтр по коду валюты (опционально)"
+// @Success 200 {object} exchangerate.ListResponse "Exchange rates ordered by currency and valid_from"
+// @Failure 400 {object} problem.Problem "Invalid currency"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /api/admin/exchange-rates [get]
+func (h *Handlers) GetExchangeRates(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "GetExchangeRates")
+	defer span.End()
+
+	ctx := r.Context()
+
+	currency := r.URL.Query().Get("currency")
+
+	rates, err := h.service.GetExchangeRates(ctx, currency)
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "", "Couldn't get exchange rates")
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.String("currency", currency),
+			zap.Error(err))
+		return
+	}
+
+	res := exchangerate.ListResponse{
+		Rates: make([]exchangerate.RateResponse, 0, len(rates)),
+	}
+
+	for _, rate := range rates {
+		res.Rates = append(res.Rates, exchangerate.RateResponse{
+			Currency:  rate.Currency,
+			ValidFrom: rate.ValidFrom,
+			Rate:      rate.Rate,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"Failed to encode response",
+			zap.Error(err))
+		h.sendProblem(w, r, problemInternal, "Failed to encode response")
+		return
+	}
+}
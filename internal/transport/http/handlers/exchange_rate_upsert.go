@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/transport/http/dto/exchangerate"
+	"subscriptions/pkg/logger"
+
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// UpsertExchangeRate creates or replaces exchange rate
+// @Summary Установка курса валюты к рублю, действующего с указанного месяца
+// @Description Курс задает стоимость одной единицы валюты в рублях и действует до следующего установленного курса
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Param currency path string true "Currency code (ISO 4217)" default(USD)
+// @Param valid_from path string true "Month from which the rate is effective in MM-YYYY format" default(01-2025)
+// @Param input body exchangerate.RateRequest true "Exchange rate"
+// @Success 200 {object} exchangerate.RateResponse "Exchange rate saved successfully"
+// @Failure 400 {object} problem.Problem "Invalid JSON, currency, valid_from or rate outside 0.000001..999999999999"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /api/admin/exchange-rates/{currency}/{valid_from} [put]
+func (h *Handlers) UpsertExchangeRate(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "UpsertExchangeRate")
+	defer span.End()
+
+	ctx := r.Context()
+
+	currency := chi.URLParam(r, "currency")
+	validFromStr := chi.URLParam(r, "valid_from")
+
+	validFrom, err := entity.ParseYearMonth(validFromStr)
+	if err != nil {
+		errStr := h.sendServiceError(w, r, invalidField("valid_from", "must be in MM-YYYY format"), "", "")
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.String("valid_from", validFromStr),
+			zap.Error(err))
+		return
+	}
+
+	var req exchangerate.RateRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"Failed to decode JSON request",
+			zap.Error(err),
+			zap.String("path", r.URL.Path),
+			zap.String("method", r.Method))
+		h.sendProblem(w, r, problemMalformedRequest, "Invalid JSON")
+		return
+	}
+
+	defer r.Body.Close()
+
+	rate, err := h.service.UpsertExchangeRate(ctx, &entity.ExchangeRate{
+		Currency:  currency,
+		ValidFrom: validFrom,
+		Rate:      req.Rate,
+	})
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "", "Couldn't save exchange rate")
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.String("currency", currency),
+			zap.Stringer("valid_from", validFrom),
+			zap.Error(err))
+		return
+	}
+
+	res := exchangerate.RateResponse{
+		Currency:  rate.Currency,
+		ValidFrom: rate.ValidFrom,
+		Rate:      rate.Rate,
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"Exchange rate saved successfully",
+		zap.Any("rate", rate))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
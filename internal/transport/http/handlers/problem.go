@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"subscriptions/internal/entity"
+	service "subscriptions/internal/services"
+	"subscriptions/internal/transport/http/dto/problem"
+	"subscriptions/pkg/logger"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// problemType описывает тип ошибки RFC 7807: URI типа стабилен для клиентов, title не зависит от конкретного случая
+type problemType struct {
+	uri    string
+	title  string
+	status int
+}
+
+var (
+	problemMalformedRequest     = problemType{"/problems/malformed-request", "Malformed request", http.StatusBadRequest}
+	problemValidation           = problemType{"/problems/validation-error", "Validation failed", http.StatusBadRequest}
+	problemNotFound             = problemType{"/problems/not-found", "Resource not found", http.StatusNotFound}
+	problemMethodNotAllowed     = problemType{"/problems/method-not-allowed", "Method not allowed", http.StatusMethodNotAllowed}
+	problemConflict             = problemType{"/problems/conflict", "Conflict with the current state of the resource", http.StatusConflict}
+	problemPreconditionFailed   = problemType{"/problems/precondition-failed", "Precondition failed", http.StatusPreconditionFailed}
+	problemExchangeRateNotFound = problemType{"/problems/exchange-rate-not-found", "Exchange rate not found", http.StatusUnprocessableEntity}
+	problemPriceRewrite         = problemType{"/problems/price-rewrite", "Change would rewrite price history", http.StatusUnprocessableEntity}
+	problemInternal             = problemType{"/problems/internal-error", "Internal server error", http.StatusInternalServerError}
+)
+
+// sendProblem отвечает ошибкой в формате application/problem+json
+func (h *Handlers) sendProblem(w http.ResponseWriter, r *http.Request, pt problemType, detail string, invalidParams ...problem.InvalidParam) {
+	res := problem.Problem{
+		Type:          pt.uri,
+		Title:         pt.title,
+		Status:        pt.status,
+		Detail:        detail,
+		Instance:      r.URL.Path,
+		RequestId:     logger.RequestIdFromCtx(r.Context()),
+		InvalidParams: invalidParams,
+	}
+
+	w.Header().Set("Content-Type", problem.ContentType)
+	w.WriteHeader(pt.status)
+	json.NewEncoder(w).Encode(res)
+}
+
+// sendServiceError отвечает ошибкой, тип которой определяется типизированной ошибкой сервиса,
+// и возвращает detail ответа для лога. notFound — detail для ErrNotFound, fallback — для остальных ошибок
+func (h *Handlers) sendServiceError(w http.ResponseWriter, r *http.Request, err error, notFound, fallback string) string {
+	trace.SpanFromContext(r.Context()).RecordError(err)
+
+	var validationErr *service.ValidationError
+
+	switch {
+	case errors.As(err, &validationErr):
+		detail := "One or more parameters are invalid"
+		params := make([]problem.InvalidParam, 0, len(validationErr.Fields))
+		for _, f := range validationErr.Fields {
+			params = append(params, problem.InvalidParam{Name: f.Field, Reason: f.Message})
+		}
+		h.sendProblem(w, r, problemValidation, detail, params...)
+		return detail
+	case errors.Is(err, service.ErrNotFound):
+		h.sendProblem(w, r, problemNotFound, notFound)
+		return notFound
+	// If-Match не совпал с текущей версией: подписку изменили после того, как клиент ее получил
+	case errors.Is(err, service.ErrPreconditionFailed):
+		detail := "The subscription has been modified since it was retrieved"
+		h.sendProblem(w, r, problemPreconditionFailed, detail)
+		return detail
+	case errors.Is(err, service.ErrConflict):
+		detail := "The request conflicts with data already stored"
+		h.sendProblem(w, r, problemConflict, detail)
+		return detail
+	// Цену начавшейся подписки меняют с нужного месяца через изменения цены, а не перезаписью подписки
+	case errors.Is(err, service.ErrPriceRewrite):
+		detail := "Price and currency of a started subscription cannot be overwritten, " +
+			"use POST /api/subscriptions/{id}/price-changes to change the price from a given month"
+		h.sendProblem(w, r, problemPriceRewrite, detail)
+		return detail
+	// Нет курса для валюты подписки или запрошенной валюты в одном из месяцев периода
+	case errors.Is(err, entity.ErrExchangeRateNotFound):
+		detail := "No exchange rate for requested currency"
+		h.sendProblem(w, r, problemExchangeRateNotFound, detail)
+		return detail
+	default:
+		trace.SpanFromContext(r.Context()).SetStatus(codes.Error, err.Error())
+		h.sendProblem(w, r, problemInternal, fallback)
+		return fallback
+	}
+}
+
+// NotFound отвечает на запросы к несуществующим маршрутам, чтобы и они получали application/problem+json
+func (h *Handlers) NotFound(w http.ResponseWriter, r *http.Request) {
+	h.sendProblem(w, r, problemNotFound, "No route for "+r.URL.Path)
+}
+
+// MethodNotAllowed отвечает на запросы к существующему маршруту с неподдерживаемым методом
+func (h *Handlers) MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	h.sendProblem(w, r, problemMethodNotAllowed, "Method "+r.Method+" is not supported for "+r.URL.Path)
+}
+
+// invalidField возвращает ошибку валидации одного поля, которое не удалось разобрать на уровне транспорта
+func invalidField(field, message string) error {
+	return &service.ValidationError{Fields: []service.FieldError{{Field: field, Message: message}}}
+}
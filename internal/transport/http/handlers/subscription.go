@@ -1,6 +1,13 @@
 package handlers
 
-import service "subscriptions/internal/services"
+import (
+	"net/http"
+	"strconv"
+	"subscriptions/internal/entity"
+	service "subscriptions/internal/services"
+	"subscriptions/internal/transport/http/dto/subscription"
+	"time"
+)
 
 type Handlers struct {
 	service service.Service
@@ -9,3 +16,129 @@ type Handlers struct {
 func New(service service.Service) *Handlers {
 	return &Handlers{service: service}
 }
+
+// toSubResponse переводит подписку в ответ API
+func toSubResponse(sub *entity.Subscription) subscription.SubResponse {
+	return subscription.SubResponse{
+		Id:              sub.Id,
+		Name:            sub.Name,
+		Price:           sub.Price.Amount,
+		UserId:          sub.UserId,
+		StartDate:       sub.StartDate,
+		EndDate:         sub.EndDate,
+		BillingPeriod:   string(sub.BillingPeriod),
+		BillingInterval: sub.BillingInterval,
+		Currency:        sub.Price.Currency,
+		Version:         sub.Version,
+		CreatedAt:       sub.CreatedAt,
+		UpdatedAt:       sub.UpdatedAt,
+		CreatedBy:       sub.CreatedBy,
+		UpdatedBy:       sub.UpdatedBy,
+		DeletedAt:       sub.DeletedAt,
+	}
+}
+
+// parsePeriodQuery разбирает период расчета из query-параметров start_date и end_date в формате MM-YYYY.
+// end_date необязателен: без него период считается до текущего месяца. Порядок дат проверяет сервис
+func parsePeriodQuery(r *http.Request) (entity.YearMonth, *entity.YearMonth, error) {
+	query := r.URL.Query()
+
+	var fields []service.FieldError
+
+	startDate, err := entity.ParseYearMonth(query.Get("start_date"))
+	if query.Get("start_date") == "" {
+		fields = append(fields, service.FieldError{Field: "start_date", Message: "is required"})
+	} else if err != nil {
+		fields = append(fields, service.FieldError{Field: "start_date", Message: "must be in MM-YYYY format"})
+	}
+
+	var endDate *entity.YearMonth
+	if query.Get("end_date") != "" {
+		date, err := entity.ParseYearMonth(query.Get("end_date"))
+		if err != nil {
+			fields = append(fields, service.FieldError{Field: "end_date", Message: "must be in MM-YYYY format"})
+		}
+		endDate = &date
+	}
+
+	if len(fields) > 0 {
+		return entity.YearMonth{}, nil, &service.ValidationError{Fields: fields}
+	}
+
+	return startDate, endDate, nil
+}
+
+// parseListQuery разбирает условия отбора и сортировку списка подписок из query-параметров.
+// Здесь проверяется только формат значений, их сочетания проверяет сервис
+func parseListQuery(r *http.Request) (entity.SubscriptionFilter, entity.Sort, error) {
+	query := r.URL.Query()
+
+	var fields []service.FieldError
+
+	filter := entity.SubscriptionFilter{
+		UserId:            query.Get("user_id"),
+		ServiceName:       query.Get("service_name"),
+		ServiceNamePrefix: query.Get("service_name_prefix"),
+		Status:            entity.SubscriptionStatus(query.Get("status")),
+	}
+
+	amount := func(name string) *entity.Amount {
+		if query.Get(name) == "" {
+			return nil
+		}
+
+		value, err := entity.ParseAmount(query.Get(name))
+		if err != nil {
+			fields = append(fields, service.FieldError{Field: name, Message: "must be a decimal amount with at most 2 fraction digits"})
+			return nil
+		}
+		return &value
+	}
+
+	month := func(name string) *entity.YearMonth {
+		if query.Get(name) == "" {
+			return nil
+		}
+
+		value, err := entity.ParseYearMonth(query.Get(name))
+		if err != nil {
+			fields = append(fields, service.FieldError{Field: name, Message: "must be in MM-YYYY format"})
+			return nil
+		}
+		return &value
+	}
+
+	filter.PriceMin = amount("price_min")
+	filter.PriceMax = amount("price_max")
+	filter.ActiveAt = month("active_at")
+	filter.StartFrom = month("start_from")
+	filter.StartTo = month("start_to")
+
+	if updatedSince := query.Get("updated_since"); updatedSince != "" {
+		value, err := time.Parse(time.RFC3339Nano, updatedSince)
+		if err != nil {
+			fields = append(fields, service.FieldError{Field: "updated_since", Message: "must be an RFC 3339 timestamp"})
+		} else {
+			filter.UpdatedSince = &value
+		}
+	}
+
+	if includeDeleted := query.Get("include_deleted"); includeDeleted != "" {
+		value, err := strconv.ParseBool(includeDeleted)
+		if err != nil {
+			fields = append(fields, service.FieldError{Field: "include_deleted", Message: "must be true or false"})
+		}
+		filter.IncludeDeleted = value
+	}
+
+	sort := entity.Sort{
+		Key:   query.Get("sort"),
+		Order: entity.SortOrder(query.Get("order")),
+	}
+
+	if len(fields) > 0 {
+		return entity.SubscriptionFilter{}, entity.Sort{}, &service.ValidationError{Fields: fields}
+	}
+
+	return filter, sort, nil
+}
@@ -2,12 +2,12 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"subscriptions/internal/entity"
 	"subscriptions/internal/transport/http/dto/subscription"
 	"subscriptions/pkg/logger"
 
-	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -15,12 +15,17 @@ import (
 // @Summary Создание новой подписки для пользователя
 // @Accept json
 // @Produce json
+// @Produce application/problem+json
 // @Param input body subscription.SubRequest true "Subscription data"
 // @Success 201 {object} subscription.SubResponse "Subscription created successful"
-// @Failure 400 {object} subscription.ErrorResponse "Invalid JSON or Invalid format for UUID in `user_id`"
-// @Failure 500 {object} subscription.ErrorResponse "Internal server error"
+// @Header 201 {string} ETag "Version of the subscription for If-Match"
+// @Failure 400 {object} problem.Problem "Invalid JSON, dates not in MM-YYYY format or validation failed with the list of invalid fields"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /api/subscriptions/ [post]
 func (h *Handlers) Create(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "Create")
+	defer span.End()
+
 	ctx := r.Context()
 
 	var req subscription.SubRequest
@@ -33,74 +38,45 @@ func (h *Handlers) Create(w http.ResponseWriter, r *http.Request) {
 			zap.String("method", r.Method),
 			zap.Any("headers", r.Header),
 		)
-		h.sendError(w, http.StatusBadRequest, "Invalid JSON")
+		errStr := "Invalid JSON"
+		if errors.Is(err, entity.ErrInvalidYearMonth) {
+			errStr = "Invalid `start_date` or `end_date`, expected MM-YYYY"
+		}
+		h.sendProblem(w, r, problemMalformedRequest, errStr)
 		return
 	}
 
 	defer r.Body.Close()
 
-	if req.Price < 0 || req.Name == "" ||
-		req.UserId == "" || req.StartDate == "" {
-		logger.GetLoggerFromCtx(ctx).Error(ctx,
-			"Empty fields in json or negative <Price>",
-			zap.String("path", r.URL.Path),
-			zap.String("method", r.Method),
-			zap.Any("headers", r.Header),
-		)
-		h.sendError(w, http.StatusBadRequest, "Invalid JSON")
-		return
-	}
-
-	_, err := uuid.Parse(req.UserId)
-
-	if err != nil {
-		errStr := "Invalid format for UUID in `user_id`"
-		h.sendError(w, http.StatusBadRequest, errStr)
-		logger.GetLoggerFromCtx(ctx).Error(ctx,
-			errStr,
-			zap.Error(err))
-		return
-	}
-
 	newSubscription := entity.Subscription{
-		Name:      req.Name,
-		Price:     req.Price,
-		UserId:    req.UserId,
-		StartDate: req.StartDate,
-		EndDate:   req.EndDate,
+		Name:            req.Name,
+		Price:           entity.Money{Amount: req.Price, Currency: req.Currency},
+		UserId:          req.UserId,
+		StartDate:       req.StartDate,
+		EndDate:         req.EndDate,
+		BillingPeriod:   entity.BillingPeriod(req.BillingPeriod),
+		BillingInterval: req.BillingInterval,
 	}
 
 	createdSub, err := h.service.Create(ctx, &newSubscription)
 
 	if err != nil {
+		h.sendServiceError(w, r, err, "", "Internal server error")
 		logger.GetLoggerFromCtx(ctx).Error(ctx,
 			"failed to create Subscription",
 			zap.Any("sub", req),
 			zap.Error(err))
-		h.sendError(w, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	res := subscription.SubResponse{
-		Id:        createdSub.Id,
-		Name:      createdSub.Name,
-		Price:     createdSub.Price,
-		UserId:    createdSub.UserId,
-		StartDate: createdSub.StartDate,
-		EndDate:   createdSub.EndDate,
-	}
+	res := toSubResponse(createdSub)
 
 	logger.GetLoggerFromCtx(ctx).Info(ctx,
 		"Subscription created successfully!",
 		zap.Any("sub", createdSub))
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(createdSub.Version))
 	w.WriteHeader(http.StatusCreated) //201
 	json.NewEncoder(w).Encode(res)
 }
-
-func (h *Handlers) sendError(w http.ResponseWriter, status int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]string{"error": message})
-}
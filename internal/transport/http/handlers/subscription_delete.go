@@ -1,36 +1,38 @@
 package handlers
 
 import (
-	"database/sql"
-	"errors"
 	"net/http"
 	"subscriptions/pkg/logger"
 
 	"github.com/go-chi/chi"
-	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
-// Delete removes subscription by ID
+// Delete soft-deletes subscription by ID
 // @Summary Удаление подписки по ID
+// @Description Удаление мягкое: подписку можно восстановить через POST /api/subscriptions/{id}/restore, пока она не очищена по сроку хранения.
 // @Accept json
 // @Produce json
+// @Produce application/problem+json
 // @Param id path string true "Subscription ID in UUID format"
+// @Param If-Match header string false "ETag from Get; the request fails with 412 if the subscription has been modified since"
 // @Success 204 "Subscription deleted successfully"
-// @Failure 400 {object} subscription.ErrorResponse "Invalid format for UUID in `id`"
-// @Failure 404 {object} subscription.ErrorResponse "Subscription not found"
-// @Failure 500 {object} subscription.ErrorResponse "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid format for UUID in `id`"
+// @Failure 404 {object} problem.Problem "Subscription not found"
+// @Failure 412 {object} problem.Problem "If-Match does not match the current version of the subscription"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /api/subscriptions/{id} [delete]
 func (h Handlers) Delete(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "Delete")
+	defer span.End()
+
 	ctx := r.Context()
 
 	idStr := chi.URLParam(r, "id")
 
-	UUID, err := uuid.Parse(idStr)
-
+	version, err := ifMatchVersion(r)
 	if err != nil {
-		errStr := "Invalid format for UUID in `id`"
-		h.sendError(w, http.StatusBadRequest, errStr)
+		errStr := h.sendServiceError(w, r, err, "", "")
 		logger.GetLoggerFromCtx(ctx).Error(ctx,
 			errStr,
 			zap.Any("id", idStr),
@@ -38,19 +40,10 @@ func (h Handlers) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id := UUID.String()
-
-	err = h.service.DeleteById(ctx, id)
+	err = h.service.DeleteById(ctx, idStr, version)
 
 	if err != nil {
-		var errStr string
-		if errors.Is(err, sql.ErrNoRows) {
-			errStr = "Subscription not found"
-			h.sendError(w, http.StatusNotFound, errStr)
-		} else {
-			errStr = "Couldn't delete subscription"
-			h.sendError(w, http.StatusInternalServerError, errStr)
-		}
+		errStr := h.sendServiceError(w, r, err, "Subscription not found", "Couldn't delete subscription")
 
 		logger.GetLoggerFromCtx(ctx).Error(ctx,
 			errStr,
@@ -61,6 +54,6 @@ func (h Handlers) Delete(w http.ResponseWriter, r *http.Request) {
 
 	logger.GetLoggerFromCtx(ctx).Info(ctx,
 		"Subscription delete successfully!",
-		zap.Any("id", id))
+		zap.Any("id", idStr))
 	w.WriteHeader(http.StatusNoContent)
 }
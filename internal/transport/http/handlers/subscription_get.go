@@ -1,15 +1,11 @@
 package handlers
 
 import (
-	"database/sql"
 	"encoding/json"
-	"errors"
 	"net/http"
-	"subscriptions/internal/transport/http/dto/subscription"
 	"subscriptions/pkg/logger"
 
 	"github.com/go-chi/chi"
-	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -17,23 +13,29 @@ import (
 // @Summary Получение подписки по ID
 // @Accept json
 // @Produce json
+// @Produce application/problem+json
 // @Param id path string true "Subscription ID in UUID format"
+// @Param If-None-Match header string false "ETag from a previous response; 304 is returned if the subscription has not changed"
 // @Success 200 {object} subscription.SubResponse "Subscription details"
-// @Failure 400 {object} subscription.ErrorResponse "Invalid format for UUID in `id`"
-// @Failure 404 {object} subscription.ErrorResponse "Subscription not found"
-// @Failure 500 {object} subscription.ErrorResponse "Internal server error"
+// @Success 304 "Subscription has not changed since the ETag in If-None-Match"
+// @Header 200,304 {string} ETag "Version of the subscription for If-Match and If-None-Match"
+// @Failure 400 {object} problem.Problem "Invalid format for UUID in `id`"
+// @Failure 404 {object} problem.Problem "Subscription not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /api/subscriptions/{id} [get]
 func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
 
+	r, span := startSpan(r, "Get")
+	defer span.End()
+
 	ctx := r.Context()
 
 	idStr := chi.URLParam(r, "id")
 
-	UUID, err := uuid.Parse(idStr)
-
+	gotSub, err := h.service.GetById(ctx, idStr)
 	if err != nil {
-		errStr := "Invalid format for UUID in `id`"
-		h.sendError(w, http.StatusBadRequest, errStr)
+		errStr := h.sendServiceError(w, r, err, "Subscription not found", "Failed to fetch subscription")
+
 		logger.GetLoggerFromCtx(ctx).Error(ctx,
 			errStr,
 			zap.Any("id", idStr),
@@ -41,34 +43,16 @@ func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id := UUID.String()
-
-	gotSub, err := h.service.GetById(ctx, id)
-	if err != nil {
-		var errStr string
-		if errors.Is(err, sql.ErrNoRows) {
-			errStr = "Subscription not found"
-			h.sendError(w, http.StatusNotFound, errStr)
-		} else {
-			errStr = "Failed to fetch subscription"
-			h.sendError(w, http.StatusInternalServerError, errStr)
-		}
+	tag := etag(gotSub.Version)
+	w.Header().Set("ETag", tag)
 
-		logger.GetLoggerFromCtx(ctx).Error(ctx,
-			errStr,
-			zap.Any("id", idStr),
-			zap.Error(err))
+	// Клиент уже видел эту версию: тело не передаем
+	if noneMatch(r, tag) {
+		w.WriteHeader(http.StatusNotModified)
 		return
 	}
 
-	res := subscription.SubResponse{
-		Id:        gotSub.Id,
-		Name:      gotSub.Name,
-		Price:     gotSub.Price,
-		UserId:    gotSub.UserId,
-		StartDate: gotSub.StartDate,
-		EndDate:   gotSub.EndDate,
-	}
+	res := toSubResponse(gotSub)
 
 	logger.GetLoggerFromCtx(ctx).Info(ctx,
 		"Subscription got successfully!",
@@ -1,42 +1,60 @@
 package handlers
 
 import (
-	"database/sql"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
+	"subscriptions/internal/entity"
 	"subscriptions/internal/transport/http/dto/subscription"
 	"subscriptions/pkg/logger"
 
-	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 // GetList returns paginated list of subscriptions with optional filtering
-// @Summary Получение списка подписок с фильтрацией по ID пользователя, названием сервиса и пагинацией
+// @Summary Получение списка подписок с фильтрацией, сортировкой и пагинацией
 // @Accept json
 // @Produce json
-// @Param page query int false "Номер страницы (опционально)" default(1)
-// @Param limit query int false "Количество элементов на странице (опционально)" default(20)
+// @Produce application/problem+json
+// @Param cursor query string false "Курсор из next_cursor предыдущей страницы (опционально)"
+// @Param page query int false "Номер страницы, устаревший режим; нельзя передавать вместе с cursor (опционально)"
+// @Param limit query int false "Количество элементов на странице, не больше 100 (опционально)" default(20)
+// @Param with_total query bool false "Вернуть total_count — общее число подписок по фильтру (опционально)" default(false)
 // @Param user_id query string false "Фильтр по ID пользователя (опционально)"
 // @Param service_name query string false "Фильтр по названию сервиса (опционально)"
+// @Param service_name_prefix query string false "Регистронезависимый поиск по началу названия сервиса (опционально)"
+// @Param price_min query string false "Минимальная цена, например 199.99 (опционально)"
+// @Param price_max query string false "Максимальная цена, например 999.99 (опционально)"
+// @Param active_at query string false "Подписка действует в месяце MM-YYYY (опционально)"
+// @Param start_from query string false "Подписка началась не раньше месяца MM-YYYY (опционально)"
+// @Param start_to query string false "Подписка началась не позже месяца MM-YYYY (опционально)"
+// @Param updated_since query string false "Подписки, измененные начиная с момента в формате RFC 3339, например 2025-07-01T00:00:00Z (опционально)"
+// @Param include_deleted query bool false "Вместе с мягко удаленными подписками, у них заполнено deleted_at (опционально)" default(false)
+// @Param status query string false "Состояние подписки относительно текущего месяца (опционально)" Enums(active, ended)
+// @Param sort query string false "Поле сортировки (опционально)" Enums(id, price, start_date, end_date, service_name, updated_at) default(id)
+// @Param order query string false "Направление сортировки (опционально)" Enums(asc, desc) default(asc)
 // @Success 200 {object} subscription.ListResponse "Success response with subscriptions list"
-// @Failure 400 {object} subscription.ErrorResponse "Invalid format for UUID in `user_id`"
-// @Failure 404 {object} subscription.ErrorResponse "Subscriptions not found"
-// @Failure 500 {object} subscription.ErrorResponse "Internal server error"
+// @Failure 400 {object} problem.Problem "Invalid filter, sort or pagination parameters"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /api/subscriptions/ [get]
 func (h *Handlers) GetList(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "GetList")
+	defer span.End()
+
 	ctx := r.Context()
 
 	pageStr := r.URL.Query().Get("page")
 	limitStr := r.URL.Query().Get("limit")
-	userId := r.URL.Query().Get("user_id")
-	serviceName := r.URL.Query().Get("service_name")
-
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page <= 0 {
-		page = 1
+	cursor := r.URL.Query().Get("cursor")
+
+	// Без page список листается курсором, page включает устаревший режим со смещением
+	page := 0
+	if pageStr != "" {
+		var err error
+		page, err = strconv.Atoi(pageStr)
+		if err != nil || page <= 0 {
+			page = 1
+		}
 	}
 
 	limit, err := strconv.Atoi(limitStr)
@@ -44,30 +62,27 @@ func (h *Handlers) GetList(w http.ResponseWriter, r *http.Request) {
 		limit = 20
 	}
 
-	if userId != "" {
-		_, err = uuid.Parse(userId)
+	pagination := entity.Pagination{Page: page, Limit: limit, Cursor: cursor}
 
+	filter, sort, err := parseListQuery(r)
+	if err == nil && r.URL.Query().Get("with_total") != "" {
+		pagination.WithTotal, err = strconv.ParseBool(r.URL.Query().Get("with_total"))
 		if err != nil {
-			errStr := "Invalid format for UUID in `user_id`"
-			h.sendError(w, http.StatusBadRequest, errStr)
-			logger.GetLoggerFromCtx(ctx).Error(ctx,
-				errStr,
-				zap.Error(err))
-			return
+			err = invalidField("with_total", "must be true or false")
 		}
 	}
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "", "")
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.String("query", r.URL.RawQuery),
+			zap.Error(err))
+		return
+	}
 
-	gotSubs, hasNext, err := h.service.GetList(ctx, page, limit, userId, serviceName)
-
+	gotList, err := h.service.GetList(ctx, filter, sort, pagination)
 	if err != nil {
-		var errStr string
-		if errors.Is(err, sql.ErrNoRows) {
-			errStr = "Subscriptions not found"
-			h.sendError(w, http.StatusNotFound, errStr)
-		} else {
-			errStr = "Failed to fetch subscriptions"
-			h.sendError(w, http.StatusInternalServerError, errStr)
-		}
+		errStr := h.sendServiceError(w, r, err, "", "Failed to fetch subscriptions")
 
 		logger.GetLoggerFromCtx(ctx).Error(ctx,
 			errStr,
@@ -75,26 +90,19 @@ func (h *Handlers) GetList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	responses := make([]subscription.SubResponse, 0, len(gotSubs))
-
-	for _, sub := range gotSubs {
-		res := subscription.SubResponse{
-			Id:        sub.Id,
-			Name:      sub.Name,
-			Price:     sub.Price,
-			UserId:    sub.UserId,
-			StartDate: sub.StartDate,
-			EndDate:   sub.EndDate,
-		}
+	responses := make([]subscription.SubResponse, 0, len(gotList.Subscriptions))
 
-		responses = append(responses, res)
+	for _, sub := range gotList.Subscriptions {
+		responses = append(responses, toSubResponse(&sub))
 	}
 
-	response := map[string]interface{}{
-		"page":          page,
-		"limit":         limit,
-		"has_next":      hasNext,
-		"subscriptions": responses,
+	response := subscription.ListResponse{
+		Page:          page,
+		Limit:         limit,
+		HasNext:       gotList.HasNext,
+		TotalCount:    gotList.TotalCount,
+		NextCursor:    gotList.NextCursor,
+		Subscriptions: responses,
 	}
 
 	logger.GetLoggerFromCtx(ctx).Info(ctx,
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"subscriptions/internal/transport/http/dto/subscription"
+	"subscriptions/pkg/logger"
+
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// GetMonthlyBreakdown returns user's spend per calendar month
+// @Summary Помесячная разбивка стоимости подписок пользователя за период
+// @Description Возвращает по одной строке на каждый календарный месяц периода (включая месяцы без трат)
+// @Description с общей суммой и списком подписок, из которых она сложилась. Без end_date период считается до текущего месяца
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Param user_id path string true "User ID in UUID format"
+// @Param start_date query string true "Start date in MM-YYYY format" default(01-2025)
+// @Param end_date query string false "End date in MM-YYYY format" default(12-2025)
+// @Param currency query string false "Валюта итогов (ISO 4217), по умолчанию RUB" default(RUB)
+// @Success 200 {object} subscription.MonthlyBreakdown "Success response with monthly time series"
+// @Failure 400 {object} problem.Problem "Invalid UUID in `user_id`, missing or invalid start_date/end_date or currency"
+// @Failure 422 {object} problem.Problem "No exchange rate for requested currency"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /api/subscriptions/summary/{user_id}/monthly [get]
+func (h *Handlers) GetMonthlyBreakdown(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "GetMonthlyBreakdown")
+	defer span.End()
+
+	ctx := r.Context()
+
+	userId := chi.URLParam(r, "user_id")
+
+	startDate, endDate, err := parsePeriodQuery(r)
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "", "")
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.Any("user_id", userId),
+			zap.String("start_date", r.URL.Query().Get("start_date")),
+			zap.String("end_date", r.URL.Query().Get("end_date")))
+		return
+	}
+
+	// Итоги пересчитываются в запрошенную валюту, по умолчанию в рубли
+	currency := r.URL.Query().Get("currency")
+
+	breakdown, err := h.service.GetMonthlyBreakdown(ctx, userId, startDate, endDate, currency)
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "", "Failed to calculate monthly breakdown")
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.String("user_id", userId),
+			zap.Stringer("start_date", startDate),
+			zap.Stringer("end_date", endDate),
+			zap.String("currency", currency),
+			zap.Error(err))
+		return
+	}
+
+	res := subscription.MonthlyBreakdown{
+		UserId:    userId,
+		StartDate: startDate,
+		Months:    make([]subscription.MonthlyCost, 0, len(breakdown)),
+	}
+
+	for _, monthly := range breakdown {
+		items := make([]subscription.MonthlyCostItem, 0, len(monthly.Subscriptions))
+
+		for _, item := range monthly.Subscriptions {
+			items = append(items, subscription.MonthlyCostItem{
+				Id:          item.Subscription.Id,
+				ServiceName: item.Subscription.Name,
+				Charges:     item.Charges,
+				Cost:        item.Cost.Amount,
+			})
+		}
+
+		res.Months = append(res.Months, subscription.MonthlyCost{
+			Month:         monthly.Month,
+			TotalCost:     monthly.TotalCost.Amount,
+			Subscriptions: items,
+		})
+		res.TotalCost += monthly.TotalCost.Amount
+		res.Currency = monthly.TotalCost.Currency
+	}
+
+	// Без end_date период считается до текущего месяца, возвращаем фактическую границу
+	if endDate != nil {
+		res.EndDate = *endDate
+	} else if len(res.Months) > 0 {
+		res.EndDate = res.Months[len(res.Months)-1].Month
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"Monthly breakdown calculated successfully",
+		zap.Stringer("total_cost", res.TotalCost),
+		zap.Int("months", len(res.Months)),
+		zap.String("user_id", userId),
+		zap.Stringer("start_date", startDate),
+		zap.Stringer("end_date", endDate))
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"Failed to encode response",
+			zap.Error(err))
+		h.sendProblem(w, r, problemInternal, "Failed to encode response")
+		return
+	}
+}
@@ -7,118 +7,124 @@ import (
 	"subscriptions/pkg/logger"
 
 	"github.com/go-chi/chi"
-	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 // @Summary Рассчитывает общую стоимость подписки для пользователя за определенный период
 // @Accept json
 // @Produce json
+// @Produce application/problem+json
 // @Param user_id path string true "User ID in UUID format"
 // @Param service_name path string true "Service name"
 // @Param start_date query string true "Start date in MM-YYYY format" default(01-2025)
 // @Param end_date query string false "End date in MM-YYYY format" default(12-2025)
-// @Success 200 {object} subscription.Summary "Success response with total cost"
-// @Failure 400 {object} subscription.ErrorResponse "Invalid format for UUID in `user_id`, empty service_name or missing start_date"
-// @Failure 404 {object} subscription.ErrorResponse "No subscriptions found for given criteria"
-// @Failure 500 {object} subscription.ErrorResponse "Internal server error"
+// @Param currency query string false "Валюта итогов (ISO 4217), по умолчанию RUB" default(RUB)
+// @Description Стоимость считается по каждой подписке как количество списаний в периоде с учетом цикла оплаты
+// @Description (billing_period, billing_interval), умноженное на цену. Годовая подписка списывается раз в год в месяц начала.
+// @Description Бессрочные подписки учитываются до конца периода, без end_date период считается до текущего месяца
+// @Success 200 {object} subscription.Summary "Success response with total cost, billed months and per-subscription breakdown"
+// @Failure 400 {object} problem.Problem "Invalid UUID in `user_id`, empty service_name, missing or invalid start_date/end_date or currency"
+// @Failure 404 {object} problem.Problem "No subscriptions found for given criteria"
+// @Failure 422 {object} problem.Problem "No exchange rate for requested currency"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /api/subscriptions/summary/{user_id}/{service_name} [get]
 func (h *Handlers) GetSummary(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
-	userIdStr := chi.URLParam(r, "user_id")
+	r, span := startSpan(r, "GetSummary")
+	defer span.End()
 
-	UUID, err := uuid.Parse(userIdStr)
-	if err != nil {
-		errStr := "Invalid format for UUID in `user_id`"
-		h.sendError(w, http.StatusBadRequest, errStr)
-		logger.GetLoggerFromCtx(ctx).Error(ctx,
-			errStr,
-			zap.Any("user_id", userIdStr),
-			zap.Error(err))
-		return
-	}
+	ctx := r.Context()
 
-	userId := UUID.String()
+	userId := chi.URLParam(r, "user_id")
 
 	serviceName := chi.URLParam(r, "service_name")
 
-	if serviceName == "" {
-		errStr := "Empty service_name"
-		h.sendError(w, http.StatusBadRequest, errStr)
-		logger.GetLoggerFromCtx(ctx).Error(ctx,
-			errStr,
-			zap.Any("user_id", userId))
-		return
-	}
-
-	startDate := r.URL.Query().Get("start_date")
-
-	if startDate == "" {
-		errStr := "Query parameter start_date empty "
-		h.sendError(w, http.StatusBadRequest, errStr)
+	startDate, endDate, err := parsePeriodQuery(r)
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "", "")
 		logger.GetLoggerFromCtx(ctx).Error(ctx,
 			errStr,
 			zap.Any("user_id", userId),
-			zap.Any("service_name", serviceName))
+			zap.Any("service_name", serviceName),
+			zap.String("start_date", r.URL.Query().Get("start_date")),
+			zap.String("end_date", r.URL.Query().Get("end_date")))
 		return
 	}
 
-	endDate := r.URL.Query().Get("end_date")
+	// Итоги пересчитываются в запрошенную валюту, по умолчанию в рубли
+	currency := r.URL.Query().Get("currency")
 
-	totalCost, err := h.service.GetSummary(ctx, userId, serviceName, startDate, endDate)
+	summary, err := h.service.GetSummary(ctx, userId, serviceName, startDate, endDate, currency)
 	if err != nil {
-		errStr := "Failed to calculate summary"
-		h.sendError(w, http.StatusInternalServerError, errStr)
+		errStr := h.sendServiceError(w, r, err, "", "Failed to calculate summary")
 
 		logger.GetLoggerFromCtx(ctx).Error(ctx,
 			errStr,
 			zap.String("service_name", serviceName),
 			zap.String("user_id", userId),
-			zap.String("start_date", startDate),
-			zap.String("end_date", endDate),
+			zap.Stringer("start_date", startDate),
+			zap.Stringer("end_date", endDate),
+			zap.String("currency", currency),
 			zap.Error(err))
 		return
 	}
 
-	if totalCost == 0 {
+	if len(summary.Subscriptions) == 0 {
 		errStr := "No subscriptions found for given criteria"
-		h.sendError(w, http.StatusNotFound, errStr)
+		h.sendProblem(w, r, problemNotFound, errStr)
 
 		logger.GetLoggerFromCtx(ctx).Error(ctx,
 			errStr,
 			zap.String("service_name", serviceName),
 			zap.String("user_id", userId),
-			zap.String("start_date", startDate),
-			zap.String("end_date", endDate))
+			zap.Stringer("start_date", startDate),
+			zap.Stringer("end_date", endDate))
 		return
 	}
 
-	res := subscription.Summary{
-		UserId:      userId,
-		ServiceName: serviceName,
-		StartDate:   startDate,
-		TotalCost:   totalCost,
+	items := make([]subscription.SummaryItem, 0, len(summary.Subscriptions))
+
+	for _, item := range summary.Subscriptions {
+		items = append(items, subscription.SummaryItem{
+			Id:              item.Subscription.Id,
+			Price:           item.Subscription.Price.Amount,
+			Currency:        item.Subscription.Price.Currency,
+			StartDate:       item.Subscription.StartDate,
+			EndDate:         item.Subscription.EndDate,
+			BillingPeriod:   string(item.Subscription.BillingPeriod),
+			BillingInterval: item.Subscription.BillingInterval,
+			Months:          item.Months,
+			Charges:         item.Charges,
+			Cost:            item.Cost.Amount,
+		})
 	}
 
-	if endDate != "" {
-		res.EndDate = endDate
+	res := subscription.Summary{
+		UserId:        userId,
+		ServiceName:   serviceName,
+		StartDate:     startDate,
+		Currency:      summary.TotalCost.Currency,
+		TotalCost:     summary.TotalCost.Amount,
+		Months:        summary.Months,
+		Subscriptions: items,
 	}
 
+	res.EndDate = endDate
+
 	logger.GetLoggerFromCtx(ctx).Info(ctx,
 		"Summary calculated successfully",
-		zap.Int("total_cost", totalCost),
+		zap.Stringer("total_cost", summary.TotalCost),
+		zap.Int("months", summary.Months),
 		zap.String("user_id", userId),
 		zap.String("service_name", serviceName),
-		zap.String("start_date", startDate),
-		zap.String("end_date", endDate))
+		zap.Stringer("start_date", startDate),
+		zap.Stringer("end_date", endDate))
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(res); err != nil {
 		logger.GetLoggerFromCtx(ctx).Error(ctx,
 			"Failed to encode response",
 			zap.Error(err))
-		h.sendError(w, http.StatusInternalServerError, "Failed to encode response")
+		h.sendProblem(w, r, problemInternal, "Failed to encode response")
 		return
 	}
 }
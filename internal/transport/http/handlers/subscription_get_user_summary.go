@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"subscriptions/internal/transport/http/dto/subscription"
+	"subscriptions/pkg/logger"
+
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// GetUserSummary returns user's total spend across all services
+// @Summary Рассчитывает общую стоимость всех подписок пользователя за период с группировкой по сервисам
+// @Description Возвращает итог по каждому сервису и общий итог. Параметр service_name ограничивает расчет одним сервисом.
+// @Description Без end_date период считается до текущего месяца
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Param user_id path string true "User ID in UUID format"
+// @Param service_name query string false "Фильтр по названию сервиса (опционально)"
+// @Param start_date query string true "Start date in MM-YYYY format" default(01-2025)
+// @Param end_date query string false "End date in MM-YYYY format" default(12-2025)
+// @Param currency query string false "Валюта итогов (ISO 4217), по умолчанию RUB" default(RUB)
+// @Success 200 {object} subscription.UserSummary "Success response with totals per service and grand total"
+// @Failure 400 {object} problem.Problem "Invalid UUID in `user_id`, missing or invalid start_date/end_date or currency"
+// @Failure 422 {object} problem.Problem "No exchange rate for requested currency"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /api/subscriptions/summary/{user_id} [get]
+func (h *Handlers) GetUserSummary(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "GetUserSummary")
+	defer span.End()
+
+	ctx := r.Context()
+
+	userId := chi.URLParam(r, "user_id")
+
+	serviceName := r.URL.Query().Get("service_name")
+
+	startDate, endDate, err := parsePeriodQuery(r)
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "", "")
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.Any("user_id", userId),
+			zap.String("start_date", r.URL.Query().Get("start_date")),
+			zap.String("end_date", r.URL.Query().Get("end_date")))
+		return
+	}
+
+	// Итоги пересчитываются в запрошенную валюту, по умолчанию в рубли
+	currency := r.URL.Query().Get("currency")
+
+	summary, err := h.service.GetUserSummary(ctx, userId, serviceName, startDate, endDate, currency)
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "", "Failed to calculate summary")
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.String("service_name", serviceName),
+			zap.String("user_id", userId),
+			zap.Stringer("start_date", startDate),
+			zap.Stringer("end_date", endDate),
+			zap.String("currency", currency),
+			zap.Error(err))
+		return
+	}
+
+	res := subscription.UserSummary{
+		UserId:      userId,
+		ServiceName: serviceName,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Currency:    summary.TotalCost.Currency,
+		TotalCost:   summary.TotalCost.Amount,
+		Months:      summary.Months,
+		Services:    make([]subscription.ServiceSummary, 0, len(summary.Services)),
+	}
+
+	for _, service := range summary.Services {
+		items := make([]subscription.SummaryItem, 0, len(service.Subscriptions))
+
+		for _, item := range service.Subscriptions {
+			items = append(items, subscription.SummaryItem{
+				Id:              item.Subscription.Id,
+				Price:           item.Subscription.Price.Amount,
+				Currency:        item.Subscription.Price.Currency,
+				StartDate:       item.Subscription.StartDate,
+				EndDate:         item.Subscription.EndDate,
+				BillingPeriod:   string(item.Subscription.BillingPeriod),
+				BillingInterval: item.Subscription.BillingInterval,
+				Months:          item.Months,
+				Charges:         item.Charges,
+				Cost:            item.Cost.Amount,
+			})
+		}
+
+		res.Services = append(res.Services, subscription.ServiceSummary{
+			ServiceName:   service.ServiceName,
+			TotalCost:     service.TotalCost.Amount,
+			Months:        service.Months,
+			Subscriptions: items,
+		})
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"User summary calculated successfully",
+		zap.Stringer("total_cost", summary.TotalCost),
+		zap.Int("services", len(summary.Services)),
+		zap.String("user_id", userId),
+		zap.String("service_name", serviceName),
+		zap.Stringer("start_date", startDate),
+		zap.Stringer("end_date", endDate))
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"Failed to encode response",
+			zap.Error(err))
+		h.sendProblem(w, r, problemInternal, "Failed to encode response")
+		return
+	}
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/transport/http/dto/subscription"
+	"subscriptions/pkg/logger"
+
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// GetHistory returns change history of subscription by ID
+// @Summary Получение журнала изменений подписки по ID
+// @Description События отдаются от новых к старым. Журнал сохраняется и после окончательного удаления подписки.
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Param id path string true "Subscription ID in UUID format"
+// @Param cursor query string false "Курсор из next_cursor предыдущей страницы (опционально)"
+// @Param limit query int false "Количество событий на странице, не больше 100 (опционально)" default(20)
+// @Success 200 {object} subscription.HistoryResponse "Page of subscription change history"
+// @Failure 400 {object} problem.Problem "Invalid format for UUID in `id` or invalid pagination parameters"
+// @Failure 404 {object} problem.Problem "Subscription has no recorded changes"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /api/subscriptions/{id}/history [get]
+func (h *Handlers) GetHistory(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "GetHistory")
+	defer span.End()
+
+	ctx := r.Context()
+
+	idStr := chi.URLParam(r, "id")
+
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+
+	pagination := entity.Pagination{Limit: limit, Cursor: r.URL.Query().Get("cursor")}
+
+	history, err := h.service.GetHistory(ctx, idStr, pagination)
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "Subscription history not found", "Failed to fetch subscription history")
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.Any("id", idStr),
+			zap.Error(err))
+		return
+	}
+
+	events := make([]subscription.EventResponse, 0, len(history.Events))
+
+	for _, event := range history.Events {
+		res := subscription.EventResponse{
+			Id:         event.Id,
+			Type:       string(event.Type),
+			Actor:      event.Actor,
+			OccurredAt: event.OccurredAt,
+			Before:     snapshotResponse(event.Before),
+			After:      snapshotResponse(event.After),
+		}
+
+		if event.PriceChange != nil {
+			change := priceChangeResponse(*event.PriceChange)
+			res.PriceChange = &change
+		}
+
+		events = append(events, res)
+	}
+
+	response := subscription.HistoryResponse{
+		Limit:      limit,
+		HasNext:    history.HasNext,
+		NextCursor: history.NextCursor,
+		Events:     events,
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"Subscription history got successfully!",
+		zap.Any("id", idStr),
+		zap.Int("events", len(events)))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// snapshotResponse переводит снимок подписки из журнала в ответ. Отсутствующий снимок остается nil
+func snapshotResponse(sub *entity.Subscription) *subscription.SubResponse {
+	if sub == nil {
+		return nil
+	}
+
+	res := toSubResponse(sub)
+	return &res
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"slices"
+	"subscriptions/internal/entity"
+	service "subscriptions/internal/services"
+	"subscriptions/pkg/logger"
+
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// Patch partially updates subscription by ID
+// @Summary Частичное обновление подписки по ID (JSON Merge Patch)
+// @Description Меняются только переданные поля. `"end_date": null` снимает дату окончания.
+// @Accept application/merge-patch+json
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Param id path string true "Subscription ID in UUID format"
+// @Param input body subscription.PatchRequest true "Fields to change"
+// @Param If-Match header string false "ETag from Get; the request fails with 412 if the subscription has been modified since"
+// @Success 200 {object} subscription.SubResponse "Updated subscription details"
+// @Header 200 {string} ETag "New version of the subscription"
+// @Failure 400 {object} problem.Problem "Invalid JSON, unknown or null fields, or validation of the patched subscription failed"
+// @Failure 404 {object} problem.Problem "Subscription not found"
+// @Failure 412 {object} problem.Problem "If-Match does not match the current version of the subscription"
+// @Failure 422 {object} problem.Problem "Price or currency change of a started subscription or currency change of a subscription with price changes; use the price-changes endpoint"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /api/subscriptions/{id} [patch]
+func (h *Handlers) Patch(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "Patch")
+	defer span.End()
+
+	ctx := r.Context()
+
+	idStr := chi.URLParam(r, "id")
+
+	defer r.Body.Close()
+
+	var doc map[string]json.RawMessage
+
+	if err := json.NewDecoder(r.Body).Decode(&doc); err != nil || doc == nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"Failed to decode JSON merge patch",
+			zap.Error(err),
+			zap.String("path", r.URL.Path),
+			zap.String("method", r.Method),
+		)
+		h.sendProblem(w, r, problemMalformedRequest, "Invalid JSON, expected an object")
+		return
+	}
+
+	patch, err := parsePatch(doc)
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "", "")
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.Any("id", idStr),
+			zap.Error(err))
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "", "")
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.Any("id", idStr),
+			zap.Error(err))
+		return
+	}
+
+	patchedSub, err := h.service.PatchById(ctx, idStr, patch, version)
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "Subscription not found", "Couldn't patch subscription")
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.Any("id", idStr),
+			zap.Error(err))
+		return
+	}
+
+	res := toSubResponse(patchedSub)
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"Subscription patched successfully!",
+		zap.Any("res", res))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(patchedSub.Version))
+	json.NewEncoder(w).Encode(res)
+}
+
+// parsePatch переводит документ JSON Merge Patch в патч подписки.
+// null допустим только для end_date: остальные поля обязательны и не могут быть удалены
+func parsePatch(doc map[string]json.RawMessage) (entity.SubscriptionPatch, error) {
+	var patch entity.SubscriptionPatch
+	var fields []service.FieldError
+
+	// decode разбирает значение поля в dst, ошибка формата копится как ошибка поля
+	decode := func(name string, raw json.RawMessage, dst interface{}) bool {
+		if err := json.Unmarshal(raw, dst); err != nil {
+			fields = append(fields, service.FieldError{Field: name, Message: "has invalid type or format"})
+			return false
+		}
+		return true
+	}
+
+	// Ключи обходятся по порядку, чтобы ошибки по полям возвращались стабильно
+	for _, name := range slices.Sorted(maps.Keys(doc)) {
+		raw := doc[name]
+
+		if string(raw) == "null" {
+			if name == "end_date" {
+				patch.ClearEndDate = true
+			} else {
+				fields = append(fields, service.FieldError{Field: name, Message: "must not be null"})
+			}
+			continue
+		}
+
+		switch name {
+		case "service_name":
+			var v string
+			if decode(name, raw, &v) {
+				patch.Name = &v
+			}
+		case "price":
+			var v entity.Amount
+			if decode(name, raw, &v) {
+				patch.Price = &v
+			}
+		case "currency":
+			var v string
+			if decode(name, raw, &v) {
+				patch.Currency = &v
+			}
+		case "user_id":
+			var v string
+			if decode(name, raw, &v) {
+				patch.UserId = &v
+			}
+		case "start_date":
+			var v entity.YearMonth
+			if decode(name, raw, &v) {
+				patch.StartDate = &v
+			}
+		case "end_date":
+			var v entity.YearMonth
+			if decode(name, raw, &v) {
+				patch.EndDate = &v
+			}
+		case "billing_period":
+			var v string
+			if decode(name, raw, &v) {
+				period := entity.BillingPeriod(v)
+				patch.BillingPeriod = &period
+			}
+		case "billing_interval":
+			var v int
+			if decode(name, raw, &v) {
+				patch.BillingInterval = &v
+			}
+		default:
+			fields = append(fields, service.FieldError{Field: name, Message: "is not a known field"})
+		}
+	}
+
+	if len(fields) > 0 {
+		return entity.SubscriptionPatch{}, &service.ValidationError{Fields: fields}
+	}
+
+	return patch, nil
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"subscriptions/internal/entity"
+	"subscriptions/internal/transport/http/dto/subscription"
+	"subscriptions/pkg/logger"
+
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// AddPriceChange changes price of subscription from the given month
+// @Summary Изменение цены подписки с указанного месяца
+// @Description Новая цена действует с effective_from до следующего изменения, расчеты за прошлые месяцы не меняются.
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Param id path string true "Subscription ID in UUID format"
+// @Param input body subscription.PriceChangeRequest true "New price and the month it takes effect from"
+// @Success 201 {object} subscription.PriceChangeResponse "Price change created"
+// @Failure 400 {object} problem.Problem "Invalid JSON, effective_from not in MM-YYYY format or outside the subscription"
+// @Failure 404 {object} problem.Problem "Subscription not found"
+// @Failure 409 {object} problem.Problem "Price change from this month already exists"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /api/subscriptions/{id}/price-changes [post]
+func (h *Handlers) AddPriceChange(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "AddPriceChange")
+	defer span.End()
+
+	ctx := r.Context()
+
+	idStr := chi.URLParam(r, "id")
+
+	var req subscription.PriceChangeRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"Failed to decode JSON request",
+			zap.Error(err),
+			zap.String("path", r.URL.Path),
+			zap.String("method", r.Method),
+		)
+		errStr := "Invalid JSON"
+		if errors.Is(err, entity.ErrInvalidYearMonth) {
+			errStr = "Invalid `effective_from`, expected MM-YYYY"
+		}
+		h.sendProblem(w, r, problemMalformedRequest, errStr)
+		return
+	}
+
+	defer r.Body.Close()
+
+	change := entity.PriceChange{
+		SubscriptionId: idStr,
+		EffectiveFrom:  req.EffectiveFrom,
+		Price:          req.Price,
+	}
+
+	added, err := h.service.AddPriceChange(ctx, &change)
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "Subscription not found", "Couldn't change subscription price")
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.Any("id", idStr),
+			zap.Any("change", req),
+			zap.Error(err))
+		return
+	}
+
+	res := priceChangeResponse(*added)
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"Subscription price changed successfully!",
+		zap.Any("res", res))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated) //201
+	json.NewEncoder(w).Encode(res)
+}
+
+// GetPriceChanges returns price changes of subscription
+// @Summary Получение изменений цены подписки
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Param id path string true "Subscription ID in UUID format"
+// @Success 200 {object} subscription.PriceChangesResponse "Price changes ordered by effective_from"
+// @Failure 400 {object} problem.Problem "Invalid format for UUID in `id`"
+// @Failure 404 {object} problem.Problem "Subscription not found"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /api/subscriptions/{id}/price-changes [get]
+func (h *Handlers) GetPriceChanges(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "GetPriceChanges")
+	defer span.End()
+
+	ctx := r.Context()
+
+	idStr := chi.URLParam(r, "id")
+
+	changes, err := h.service.GetPriceChanges(ctx, idStr)
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "Subscription not found", "Failed to fetch price changes")
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.Any("id", idStr),
+			zap.Error(err))
+		return
+	}
+
+	response := subscription.PriceChangesResponse{
+		PriceChanges: make([]subscription.PriceChangeResponse, 0, len(changes)),
+	}
+
+	for _, change := range changes {
+		response.PriceChanges = append(response.PriceChanges, priceChangeResponse(change))
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"Price changes got successfully!",
+		zap.Any("id", idStr),
+		zap.Int("count", len(changes)))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func priceChangeResponse(change entity.PriceChange) subscription.PriceChangeResponse {
+	return subscription.PriceChangeResponse{
+		SubscriptionId: change.SubscriptionId,
+		EffectiveFrom:  change.EffectiveFrom,
+		Price:          change.Price,
+		CreatedAt:      change.CreatedAt,
+		CreatedBy:      change.CreatedBy,
+	}
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"subscriptions/pkg/logger"
+
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// Restore restores soft-deleted subscription by ID
+// @Summary Восстановление удаленной подписки по ID
+// @Description Восстановление идемпотентно: для действующей подписки возвращается она сама без изменений.
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Param id path string true "Subscription ID in UUID format"
+// @Param If-Match header string false "Version from the list with include_deleted=true; the request fails with 412 if the subscription has been modified since"
+// @Success 200 {object} subscription.SubResponse "Restored subscription details"
+// @Header 200 {string} ETag "New version of the subscription"
+// @Failure 400 {object} problem.Problem "Invalid format for UUID in `id`"
+// @Failure 404 {object} problem.Problem "Subscription not found or already purged"
+// @Failure 412 {object} problem.Problem "If-Match does not match the current version of the subscription"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Router /api/subscriptions/{id}/restore [post]
+func (h *Handlers) Restore(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "Restore")
+	defer span.End()
+
+	ctx := r.Context()
+
+	idStr := chi.URLParam(r, "id")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "", "")
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.Any("id", idStr),
+			zap.Error(err))
+		return
+	}
+
+	restoredSub, err := h.service.RestoreById(ctx, idStr, version)
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "Subscription not found", "Couldn't restore subscription")
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			errStr,
+			zap.Any("id", idStr),
+			zap.Error(err))
+		return
+	}
+
+	res := toSubResponse(restoredSub)
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"Subscription restored successfully!",
+		zap.Any("res", res))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(restoredSub.Version))
+	json.NewEncoder(w).Encode(res)
+}
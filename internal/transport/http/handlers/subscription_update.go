@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"database/sql"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -10,7 +9,6 @@ import (
 	"subscriptions/pkg/logger"
 
 	"github.com/go-chi/chi"
-	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -18,32 +16,26 @@ import (
 // @Summary Обновление подписки по ID
 // @Accept json
 // @Produce json
+// @Produce application/problem+json
 // @Param id path string true "Subscription ID in UUID format"
 // @Param input body subscription.SubRequest true "Subscription update data"
+// @Param If-Match header string false "ETag from Get; the request fails with 412 if the subscription has been modified since"
 // @Success 200 {object} subscription.SubResponse "Updated subscription details"
-// @Failure 400 {object} subscription.ErrorResponse "Invalid format for UUID in `id`, JSON, or validation error"
-// @Failure 404 {object} subscription.ErrorResponse "Subscription not found"
-// @Failure 500 {object} subscription.ErrorResponse "Internal server error"
+// @Header 200 {string} ETag "New version of the subscription"
+// @Failure 400 {object} problem.Problem "Invalid JSON, dates not in MM-YYYY format or validation failed with the list of invalid fields (including `id`)"
+// @Failure 404 {object} problem.Problem "Subscription not found"
+// @Failure 412 {object} problem.Problem "If-Match does not match the current version of the subscription"
+// @Failure 422 {object} problem.Problem "Price or currency change of a started subscription or currency change of a subscription with price changes; use the price-changes endpoint"
+// @Failure 500 {object} problem.Problem "Internal server error"
 // @Router /api/subscriptions/{id} [put]
 func (h *Handlers) Put(w http.ResponseWriter, r *http.Request) {
+	r, span := startSpan(r, "Put")
+	defer span.End()
+
 	ctx := r.Context()
 
 	idStr := chi.URLParam(r, "id")
 
-	UUID, err := uuid.Parse(idStr)
-
-	if err != nil {
-		errStr := "Invalid format for UUID in `id`"
-		h.sendError(w, http.StatusBadRequest, errStr)
-		logger.GetLoggerFromCtx(ctx).Error(ctx,
-			errStr,
-			zap.Any("id", idStr),
-			zap.Error(err))
-		return
-	}
-
-	id := UUID.String()
-
 	var req subscription.SubRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -54,44 +46,42 @@ func (h *Handlers) Put(w http.ResponseWriter, r *http.Request) {
 			zap.String("method", r.Method),
 			zap.Any("headers", r.Header),
 		)
-		h.sendError(w, http.StatusBadRequest, "Invalid JSON")
+		errStr := "Invalid JSON"
+		if errors.Is(err, entity.ErrInvalidYearMonth) {
+			errStr = "Invalid `start_date` or `end_date`, expected MM-YYYY"
+		}
+		h.sendProblem(w, r, problemMalformedRequest, errStr)
 		return
 	}
 
 	defer r.Body.Close()
 
-	if req.Price < 0 || req.Name == "" ||
-		req.UserId == "" || req.StartDate == "" {
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		errStr := h.sendServiceError(w, r, err, "", "")
 		logger.GetLoggerFromCtx(ctx).Error(ctx,
-			"Empty fields in json or negative <Price>",
-			zap.String("path", r.URL.Path),
-			zap.String("method", r.Method),
-			zap.Any("headers", r.Header),
-		)
-		h.sendError(w, http.StatusBadRequest, "Invalid JSON")
+			errStr,
+			zap.Any("id", idStr),
+			zap.Error(err))
 		return
 	}
 
 	updateSubscription := entity.Subscription{
-		Id:        id,
-		Name:      req.Name,
-		Price:     req.Price,
-		UserId:    req.UserId,
-		StartDate: req.StartDate,
-		EndDate:   req.EndDate,
+		Id:              idStr,
+		Name:            req.Name,
+		Price:           entity.Money{Amount: req.Price, Currency: req.Currency},
+		UserId:          req.UserId,
+		StartDate:       req.StartDate,
+		EndDate:         req.EndDate,
+		BillingPeriod:   entity.BillingPeriod(req.BillingPeriod),
+		BillingInterval: req.BillingInterval,
+		Version:         version,
 	}
 
 	putSub, err := h.service.UpdateById(ctx, &updateSubscription)
 
 	if err != nil {
-		var errStr string
-		if errors.Is(err, sql.ErrNoRows) {
-			errStr = "Subscription not found"
-			h.sendError(w, http.StatusNotFound, errStr)
-		} else {
-			errStr = "Couldn't renew subscription"
-			h.sendError(w, http.StatusInternalServerError, errStr)
-		}
+		errStr := h.sendServiceError(w, r, err, "Subscription not found", "Couldn't renew subscription")
 
 		logger.GetLoggerFromCtx(ctx).Error(ctx,
 			errStr,
@@ -100,19 +90,13 @@ func (h *Handlers) Put(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res := subscription.SubResponse{
-		Id:        putSub.Id,
-		Name:      putSub.Name,
-		Price:     putSub.Price,
-		UserId:    putSub.UserId,
-		StartDate: putSub.StartDate,
-		EndDate:   putSub.EndDate,
-	}
+	res := toSubResponse(putSub)
 
 	logger.GetLoggerFromCtx(ctx).Info(ctx,
 		"Subscription put successfully!",
 		zap.Any("res", res))
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(putSub.Version))
 	json.NewEncoder(w).Encode(res)
 }
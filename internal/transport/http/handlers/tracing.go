@@ -0,0 +1,14 @@
+package handlers
+
+import (
+	"net/http"
+	"subscriptions/pkg/tracing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startSpan открывает спан обработчика внутри серверного спана запроса и возвращает запрос с его контекстом
+func startSpan(r *http.Request, handler string) (*http.Request, trace.Span) {
+	ctx, span := tracing.Tracer().Start(r.Context(), "Handlers."+handler)
+	return r.WithContext(ctx), span
+}
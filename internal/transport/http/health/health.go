@@ -0,0 +1,127 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"subscriptions/internal/transport/http/dto/health"
+	"subscriptions/pkg/logger"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// Время на проверку базы, чтобы зависшее соединение не блокировало healthcheck
+const checkTimeout = 2 * time.Second
+
+// Pool — часть pgxpool.Pool, нужная для проверки готовности
+type Pool interface {
+	Ping(ctx context.Context) error
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Stat() *pgxpool.Stat
+}
+
+type Handler struct {
+	pool         Pool
+	shuttingDown atomic.Bool
+}
+
+func New(pool Pool) *Handler {
+	return &Handler{pool: pool}
+}
+
+// Shutdown переводит сервис в состояние not ready, чтобы балансировщик перестал присылать новые запросы
+func (h *Handler) Shutdown() {
+	h.shuttingDown.Store(true)
+}
+
+// Live reports that the process is running
+// @Summary Проверка, что процесс жив
+// @Produce json
+// @Success 200 {object} health.Response "Service is alive"
+// @Router /health/live [get]
+func (h *Handler) Live(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, health.Response{Status: "alive"})
+}
+
+// Ready reports whether the service can handle requests
+// @Summary Проверка готовности принимать запросы
+// @Description Проверяет соединение с базой, версию миграций golang-migrate и статистику пула соединений.
+// @Description Во время graceful shutdown возвращает 503
+// @Produce json
+// @Success 200 {object} health.Response "Service is ready"
+// @Failure 503 {object} health.Response "Service is shutting down, database is unavailable or migration is dirty"
+// @Router /health/ready [get]
+func (h *Handler) Ready(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.shuttingDown.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, health.Response{Status: "not_ready", Error: "service is shutting down"})
+		return
+	}
+
+	res := health.Response{Status: "ready", Pool: poolStats(h.pool.Stat())}
+
+	migration, err := h.check(ctx)
+	res.Migration = migration
+	if err != nil {
+		res.Status = "not_ready"
+		res.Error = err.Error()
+
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"Readiness check failed",
+			zap.Error(err))
+
+		writeJSON(w, http.StatusServiceUnavailable, res)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// check пингует базу и читает версию схемы из таблицы golang-migrate
+func (h *Handler) check(ctx context.Context) (*health.Migration, error) {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	if err := h.pool.Ping(ctx); err != nil {
+		return nil, errors.New("database is unavailable")
+	}
+
+	var migration health.Migration
+
+	err := h.pool.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).
+		Scan(&migration.Version, &migration.Dirty)
+	if err != nil {
+		return nil, errors.New("unable to read migration version")
+	}
+
+	// Миграция упала на середине, схема в неизвестном состоянии
+	if migration.Dirty {
+		return &migration, errors.New("migration is dirty")
+	}
+
+	return &migration, nil
+}
+
+func poolStats(stat *pgxpool.Stat) *health.Pool {
+	return &health.Pool{
+		TotalConns:        stat.TotalConns(),
+		IdleConns:         stat.IdleConns(),
+		AcquiredConns:     stat.AcquiredConns(),
+		ConstructingConns: stat.ConstructingConns(),
+		MaxConns:          stat.MaxConns(),
+		AcquireCount:      stat.AcquireCount(),
+		EmptyAcquireCount: stat.EmptyAcquireCount(),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, res health.Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"subscriptions/pkg/logger"
+	"time"
+
+	"github.com/go-chi/chi"
+	chimiddleware "github.com/go-chi/chi/middleware"
+	"go.uber.org/zap"
+)
+
+// Logger кладет общий логгер в контекст каждого запроса
+func Logger(log *logger.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(logger.WithLogger(r.Context(), log)))
+		})
+	}
+}
+
+// AccessLog пишет одну строку на запрос: метод, шаблон маршрута, статус, размер ответа и время обработки
+func AccessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		start := time.Now()
+
+		ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		// Обработчик не вызвал WriteHeader — net/http ответит 200
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		// Шаблон маршрута известен только после роутинга, для несуществующих путей он пустой
+		route := ""
+		if rctx := chi.RouteContext(ctx); rctx != nil {
+			route = rctx.RoutePattern()
+		}
+
+		logger.GetLoggerFromCtx(ctx).Access(ctx,
+			"HTTP request",
+			zap.String("method", r.Method),
+			zap.String("route", route),
+			zap.Int("status", status),
+			zap.Int("bytes", ww.BytesWritten()),
+			zap.Duration("latency", time.Since(start)))
+	})
+}
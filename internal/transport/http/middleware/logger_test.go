@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"subscriptions/pkg/logger"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger_InjectsSharedLogger(t *testing.T) {
+	shared := logger.GetLoggerFromCtx(context.Background()).With()
+
+	r := chi.NewRouter()
+	r.Use(Logger(shared), RequestId, AccessLog)
+	r.Get("/api/subscriptions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		assert.Same(t, shared, logger.GetLoggerFromCtx(r.Context()))
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/subscriptions/42", nil))
+
+	assert.Equal(t, http.StatusTeapot, w.Code)
+	assert.NotEmpty(t, w.Header().Get(RequestIdHeader))
+}
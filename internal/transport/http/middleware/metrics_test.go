@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_LabelsByRoutePattern(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	r := chi.NewRouter()
+	r.Use(Metrics(reg))
+	r.Get("/api/subscriptions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	for _, id := range []string{"1", "2"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/subscriptions/"+id, nil))
+	}
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	require.NotEmpty(t, families)
+
+	assert.Equal(t, 2, testutil.CollectAndCount(reg, "http_request_duration_seconds"))
+
+	expected := `
+		# HELP http_requests_in_flight Number of HTTP requests being served
+		# TYPE http_requests_in_flight gauge
+		http_requests_in_flight 0
+	`
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "http_requests_in_flight"))
+
+	var counts []string
+	for _, family := range families {
+		if family.GetName() != "http_request_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			counts = append(counts, fmt.Sprintf("%s %s %d", labels["route"], labels["status"], metric.GetHistogram().GetSampleCount()))
+		}
+	}
+
+	assert.ElementsMatch(t, []string{"/api/subscriptions/{id} 404 2", "unmatched 404 1"}, counts)
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+	"subscriptions/pkg/auth"
+)
+
+const maxPrincipalLength = 255
+
+// Principal берет идентификатор аутентифицированного пользователя из заголовка header и кладет его в контекст.
+// Сервис сам не аутентифицирует запросы: заголовок выставляет шлюз перед ним и должен удалять его из запросов клиентов.
+// Запрос без заголовка или с некорректным значением обрабатывается как анонимный
+func Principal(header string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal := r.Header.Get(header)
+			if principal == "" || !isPrintableASCII(principal, maxPrincipalLength) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"subscriptions/pkg/auth"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serveWithPrincipal(header string) string {
+	var ctxPrincipal string
+
+	handler := Principal("X-Authenticated-User")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxPrincipal = auth.PrincipalFromCtx(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	if header != "" {
+		req.Header.Set("X-Authenticated-User", header)
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	return ctxPrincipal
+}
+
+func TestPrincipal_PassesHeaderValue(t *testing.T) {
+	assert.Equal(t, "alice@example.com", serveWithPrincipal("alice@example.com"))
+}
+
+func TestPrincipal_AnonymousWithoutValidHeader(t *testing.T) {
+	for _, header := range []string{"", "alice smith", strings.Repeat("a", maxPrincipalLength+1)} {
+		assert.Empty(t, serveWithPrincipal(header), "header %q", header)
+	}
+}
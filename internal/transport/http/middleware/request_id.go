@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"subscriptions/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// RequestIdHeader — заголовок, в котором ID запроса принимается от клиента и возвращается в ответе
+const RequestIdHeader = "X-Request-ID"
+
+const maxRequestIdLength = 128
+
+// RequestId берет ID запроса из X-Request-ID или генерирует новый, кладет его в контекст и возвращает в ответе
+func RequestId(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestId := r.Header.Get(RequestIdHeader)
+		if !isValidRequestId(requestId) {
+			requestId = uuid.NewString()
+		}
+
+		w.Header().Set(RequestIdHeader, requestId)
+
+		next.ServeHTTP(w, r.WithContext(logger.WithRequestId(r.Context(), requestId)))
+	})
+}
+
+// isValidRequestId пропускает только короткие ID из печатных ASCII-символов, чтобы клиент не мог испортить логи
+func isValidRequestId(requestId string) bool {
+	return requestId != "" && isPrintableASCII(requestId, maxRequestIdLength)
+}
+
+// isPrintableASCII проверяет, что значение заголовка не длиннее maxLength и состоит из печатных ASCII-символов без пробелов
+func isPrintableASCII(value string, maxLength int) bool {
+	if len(value) > maxLength {
+		return false
+	}
+
+	for i := 0; i < len(value); i++ {
+		if value[i] < '!' || value[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"subscriptions/pkg/logger"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveWithRequestId(header string) (*httptest.ResponseRecorder, string) {
+	var ctxRequestId string
+
+	handler := RequestId(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxRequestId = logger.RequestIdFromCtx(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if header != "" {
+		req.Header.Set(RequestIdHeader, header)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	return w, ctxRequestId
+}
+
+func TestRequestId_PassesClientId(t *testing.T) {
+	w, ctxRequestId := serveWithRequestId("client-req-42")
+
+	assert.Equal(t, "client-req-42", ctxRequestId)
+	assert.Equal(t, "client-req-42", w.Header().Get(RequestIdHeader))
+}
+
+func TestRequestId_GeneratesId(t *testing.T) {
+	for _, header := range []string{"", "bad id", strings.Repeat("a", maxRequestIdLength+1)} {
+		w, ctxRequestId := serveWithRequestId(header)
+
+		_, err := uuid.Parse(ctxRequestId)
+		assert.NoError(t, err)
+		assert.Equal(t, ctxRequestId, w.Header().Get(RequestIdHeader))
+	}
+}
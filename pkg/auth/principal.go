@@ -0,0 +1,20 @@
+package auth
+
+import "context"
+
+type principalKey struct{}
+
+// WithPrincipal кладет в контекст идентификатор аутентифицированного пользователя или сервиса
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromCtx возвращает идентификатор из контекста или пустую строку для анонимного запроса
+func PrincipalFromCtx(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	principal, _ := ctx.Value(principalKey{}).(string)
+	return principal
+}
@@ -2,8 +2,17 @@ package logger
 
 import (
 	"context"
-
+	"fmt"
+	"net/http"
+	"os"
+	"subscriptions/internal/config"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 const (
@@ -13,31 +22,112 @@ const (
 // Обертка для работы с контекстом
 type Logger struct {
 	zap *zap.Logger
+	// access пишет в те же приемники без сэмплирования, чтобы ни одна строка access-лога не терялась
+	access *zap.Logger
+	level  zap.AtomicLevel
 }
 
 type ctxKey struct{}
 
-func New(ctx context.Context) (context.Context, error) {
-	if GetLoggerFromCtx(ctx) != nil {
+// Логгер по умолчанию для контекстов без логгера, создается один раз
+var (
+	defaultOnce   sync.Once
+	defaultLogger *Logger
+)
+
+// New создает логгер по настройкам из конфига и кладет его в контекст
+func New(ctx context.Context, cfg *config.Config) (context.Context, error) {
+	if loggerFromContext(ctx) != nil {
 		return ctx, nil
 	}
 
-	logger, err := zap.NewDevelopment(zap.AddCaller())
+	logger, err := build(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	ctx = context.WithValue(ctx, ctxKey{}, &Logger{logger})
+	ctx = WithLogger(ctx, logger)
 	return ctx, nil
 }
 
+func build(cfg *config.Config) (*Logger, error) {
+	level, err := zap.ParseAtomicLevel(cfg.LogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level: %w", err)
+	}
+
+	encoderCfg := zap.NewProductionEncoderConfig()
+	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	var encoder zapcore.Encoder
+	switch cfg.LogFormat {
+	case "json":
+		encoder = zapcore.NewJSONEncoder(encoderCfg)
+	case "console":
+		encoderCfg.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(encoderCfg)
+	default:
+		return nil, fmt.Errorf("invalid log format %q, expected json or console", cfg.LogFormat)
+	}
+
+	sink := zapcore.Lock(os.Stdout)
+
+	// Файл ротируется по размеру, старые файлы удаляются по количеству и возрасту
+	if cfg.LogFile != "" {
+		sink = zapcore.NewMultiWriteSyncer(sink, zapcore.AddSync(&lumberjack.Logger{
+			Filename:   cfg.LogFile,
+			MaxSize:    cfg.LogFileMaxSizeMB,
+			MaxBackups: cfg.LogFileMaxBackups,
+			MaxAge:     cfg.LogFileMaxAgeDays,
+			Compress:   cfg.LogFileCompress,
+		}))
+	}
+
+	accessCore := zapcore.NewCore(encoder, sink, level)
+	core := accessCore
+
+	if cfg.LogSamplingInitial > 0 {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, cfg.LogSamplingInitial, cfg.LogSamplingThereafter)
+	}
+
+	// Пропускаем кадр обертки, чтобы caller указывал на место вызова Info/Error
+	return &Logger{
+		zap:    zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1)),
+		access: zap.New(accessCore, zap.AddCaller(), zap.AddCallerSkip(1)),
+		level:  level,
+	}, nil
+}
+
+// WithLogger кладет логгер в контекст, чтобы все обработчики запроса писали через один экземпляр
+func WithLogger(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, logger)
+}
+
+// WithRequestId кладет ID запроса в контекст, логгер добавляет его к каждой записи
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, RequestId, requestId)
+}
+
+// RequestIdFromCtx возвращает ID запроса из контекста или пустую строку
+func RequestIdFromCtx(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	requestId, _ := ctx.Value(RequestId).(string)
+	return requestId
+}
+
 func GetLoggerFromCtx(ctx context.Context) *Logger {
 	if logger := loggerFromContext(ctx); logger != nil {
 		return logger
 	}
 
-	zapLogger, _ := zap.NewDevelopment(zap.AddCaller())
-	return &Logger{zap: zapLogger}
+	defaultOnce.Do(func() {
+		zapLogger, _ := zap.NewDevelopment(zap.AddCaller(), zap.AddCallerSkip(1))
+		defaultLogger = &Logger{zap: zapLogger, access: zapLogger, level: zap.NewAtomicLevelAt(zap.DebugLevel)}
+	})
+	return defaultLogger
 }
 
 func loggerFromContext(ctx context.Context) *Logger {
@@ -56,10 +146,17 @@ func (l *Logger) addContextFields(ctx context.Context, fields []zap.Field) []zap
 		return fields
 	}
 
-	if requestId, ok := ctx.Value(RequestId).(string); ok && requestId != "" {
+	if requestId := RequestIdFromCtx(ctx); requestId != "" {
 		fields = append(fields, zap.String("request_id", requestId))
 	}
 
+	// По trace_id запись лога находится в трейсе запроса и наоборот
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		fields = append(fields,
+			zap.String("trace_id", spanCtx.TraceID().String()),
+			zap.String("span_id", spanCtx.SpanID().String()))
+	}
+
 	return fields
 }
 
@@ -78,11 +175,30 @@ func (l *Logger) Fatal(ctx context.Context, msg string, fields ...zap.Field) {
 	l.zap.Fatal(msg, fields...)
 }
 
+// Access пишет строку access-лога. Все такие строки имеют одно сообщение, поэтому сэмплирование
+// отбросило бы почти все из них под нагрузкой; Access его обходит
+func (l *Logger) Access(ctx context.Context, msg string, fields ...zap.Field) {
+	fields = l.addContextFields(ctx, fields)
+	l.access.Info(msg, fields...)
+}
+
 func (l *Logger) Debug(ctx context.Context, msg string, fields ...zap.Field) {
 	fields = l.addContextFields(ctx, fields)
 	l.zap.Debug(msg, fields...)
 }
 
 func (l *Logger) With(fields ...zap.Field) *Logger {
-	return &Logger{zap: l.zap.With(fields...)}
+	return &Logger{zap: l.zap.With(fields...), access: l.access.With(fields...), level: l.level}
+}
+
+// LevelHandler позволяет менять уровень логов без перезапуска:
+// GET возвращает текущий уровень, PUT с телом {"level":"debug"} устанавливает новый
+func (l *Logger) LevelHandler() http.Handler {
+	return l.level
+}
+
+// Sync сбрасывает буферы логгера, вызывается перед завершением приложения
+func (l *Logger) Sync() error {
+	// Оба логгера пишут в одни приемники, достаточно сбросить один
+	return l.zap.Sync()
 }
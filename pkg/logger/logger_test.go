@@ -0,0 +1,125 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"subscriptions/internal/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+func testConfig(t *testing.T) *config.Config {
+	return &config.Config{
+		LogLevel:          "info",
+		LogFormat:         "json",
+		LogFile:           filepath.Join(t.TempDir(), "app.log"),
+		LogFileMaxSizeMB:  1,
+		LogFileMaxBackups: 1,
+	}
+}
+
+func TestNew_WritesJSONToFile(t *testing.T) {
+	cfg := testConfig(t)
+
+	ctx, err := New(context.Background(), cfg)
+	require.NoError(t, err)
+
+	log := GetLoggerFromCtx(ctx)
+	log.Debug(ctx, "hidden")
+	log.Info(WithRequestId(ctx, "req-1"), "visible", zap.String("key", "value"))
+	// Sync stdout в тестовом окружении может вернуть ошибку, файл при этом уже записан
+	_ = log.Sync()
+
+	data, err := os.ReadFile(cfg.LogFile)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 1)
+	assert.Contains(t, lines[0], `"msg":"visible"`)
+	assert.Contains(t, lines[0], `"key":"value"`)
+	assert.Contains(t, lines[0], `"request_id":"req-1"`)
+	assert.Contains(t, lines[0], `"caller":"logger/logger_test.go`)
+}
+
+func TestLogger_AddsTraceFields(t *testing.T) {
+	cfg := testConfig(t)
+
+	ctx, err := New(context.Background(), cfg)
+	require.NoError(t, err)
+
+	traceId, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanId, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceId,
+		SpanID:  spanId,
+	}))
+
+	log := GetLoggerFromCtx(ctx)
+	log.Info(ctx, "traced")
+	_ = log.Sync()
+
+	data, err := os.ReadFile(cfg.LogFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"`)
+	assert.Contains(t, string(data), `"span_id":"00f067aa0ba902b7"`)
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.LogLevel = "loud"
+
+	_, err := New(context.Background(), cfg)
+	assert.ErrorContains(t, err, "invalid log level")
+
+	cfg = testConfig(t)
+	cfg.LogFormat = "xml"
+
+	_, err = New(context.Background(), cfg)
+	assert.ErrorContains(t, err, "invalid log format")
+}
+
+func TestLogger_LevelHandler(t *testing.T) {
+	ctx, err := New(context.Background(), testConfig(t))
+	require.NoError(t, err)
+
+	log := GetLoggerFromCtx(ctx)
+
+	w := httptest.NewRecorder()
+	log.LevelHandler().ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"level":"debug"}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	log.With(zap.String("component", "test")).LevelHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.JSONEq(t, `{"level":"debug"}`, w.Body.String())
+}
+
+func TestLogger_AccessBypassesSampling(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.LogSamplingInitial = 1
+	cfg.LogSamplingThereafter = 1000
+
+	ctx, err := New(context.Background(), cfg)
+	require.NoError(t, err)
+
+	// Одинаковые записи после первой отбрасываются сэмплированием, строки access-лога — нет
+	log := GetLoggerFromCtx(ctx)
+	for range 3 {
+		log.Info(ctx, "repeated")
+		log.Access(ctx, "HTTP request")
+	}
+	_ = log.Sync()
+
+	data, err := os.ReadFile(cfg.LogFile)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, strings.Count(string(data), `"msg":"repeated"`))
+	assert.Equal(t, 3, strings.Count(string(data), `"msg":"HTTP request"`))
+}
@@ -24,7 +24,15 @@ func New(ctx context.Context, cfg *config.Config) (*pgxpool.Pool, error) {
 		cfg.MinConns,
 	)
 
-	pool, err := pgxpool.New(ctx, connString)
+	poolCfg, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse database config: %w", err)
+	}
+
+	// Каждый запрос получает спан, вложенный в спан сервиса из контекста
+	poolCfg.ConnConfig.Tracer = NewQueryTracer()
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
 
 		return nil, fmt.Errorf("unable to connect to database: %w", err)